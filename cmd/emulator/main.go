@@ -5,6 +5,8 @@
 package main
 
 import (
+	"time"
+
 	"github.com/ozgen/openapi-emulator/config"
 	"github.com/ozgen/openapi-emulator/internal/server"
 	"github.com/ozgen/openapi-emulator/logger"
@@ -15,12 +17,24 @@ func main() {
 	log := logger.GetLogger()
 
 	srv, err := server.New(server.Config{
-		Port:           cfg.ServerPort,
-		SpecPath:       cfg.SpecPath,
-		SamplesDir:     cfg.SamplesDir,
-		FallbackMode:   cfg.FallbackMode,
-		ValidationMode: cfg.ValidationMode,
-		Layout:         cfg.Layout,
+		Port:               cfg.ServerPort,
+		SpecPath:           cfg.SpecPath,
+		SamplesDir:         cfg.SamplesDir,
+		FallbackMode:       cfg.FallbackMode,
+		ValidationMode:     cfg.ValidationMode,
+		SpecValidation:     cfg.SpecValidation,
+		ResponseValidation: cfg.ResponseValidation,
+		Negotiation:        cfg.Negotiation,
+		ErrorFormat:        cfg.ErrorFormat,
+		Layout:             cfg.Layout,
+		BasePathMode:       cfg.BasePathMode,
+		Mounts:             cfg.Mounts,
+		Watch:              cfg.Watch.Enabled,
+		WatchInterval:      time.Duration(cfg.Watch.IntervalMs) * time.Millisecond,
+		Security:           cfg.Security,
+		Generator:          cfg.Generator,
+		Pagination:         cfg.Pagination,
+		Templating:         cfg.Templating,
 	})
 	if err != nil {
 		log.Fatalf("failed to init server: %v", err)
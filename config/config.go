@@ -5,6 +5,8 @@
 package config
 
 import (
+	"strings"
+
 	"github.com/joho/godotenv"
 	"github.com/ozgen/openapi-emulator/utils"
 )
@@ -29,6 +31,44 @@ type ValidationMode string
 const (
 	ValidationNone     ValidationMode = "none"
 	ValidationRequired ValidationMode = "required"
+	ValidationStrict   ValidationMode = "strict" // full request validation against the operation
+)
+
+type ResponseValidationMode string
+
+const (
+	ResponseValidationNone   ResponseValidationMode = "none"
+	ResponseValidationLog    ResponseValidationMode = "log"    // log mismatches
+	ResponseValidationHeader ResponseValidationMode = "header" // log and add a warning header
+	ResponseValidationFail   ResponseValidationMode = "fail"   // replace the response with a 500
+)
+
+type NegotiationMode string
+
+const (
+	NegotiationStrict  NegotiationMode = "strict"  // 415 and 406 for media types the operation does not declare
+	NegotiationLenient NegotiationMode = "lenient" // never reject, pick the response media type from Accept where possible
+)
+
+type ErrorFormat string
+
+const (
+	ErrorFormatProblem ErrorFormat = "problem" // RFC 7807 application/problem+json
+	ErrorFormatSpec    ErrorFormat = "spec"    // the operation's declared error response
+)
+
+type GeneratorMode string
+
+const (
+	GeneratorStatic GeneratorMode = "static" // fixed placeholder values
+	GeneratorFake   GeneratorMode = "fake"   // seeded, realistic values
+)
+
+type SpecValidationMode string
+
+const (
+	SpecValidationLenient SpecValidationMode = "lenient" // report issues, keep serving
+	SpecValidationStrict  SpecValidationMode = "strict"  // refuse to load an invalid spec
 )
 
 type LayoutMode string
@@ -39,21 +79,78 @@ const (
 	LayoutFlat    LayoutMode = "flat"    // only flat
 )
 
+type BasePathMode string
+
+const (
+	BasePathAuto     BasePathMode = "auto"     // with or without the spec base path
+	BasePathRequired BasePathMode = "required" // only with the spec base path
+	BasePathIgnore   BasePathMode = "ignore"   // only without, spec paths as written
+)
+
 type ScenarioConfig struct {
 	Enabled  bool
 	Filename string
 }
 
+type WatchConfig struct {
+	Enabled    bool
+	IntervalMs int
+}
+
+// SecurityConfig enforces the security requirements of the spec.
+// Credentials lists the accepted credentials per security scheme name; a
+// scheme without entries accepts any credential that is present.
+type SecurityConfig struct {
+	Enabled     bool
+	Credentials map[string][]string
+}
+
+// GeneratorConfig controls bodies generated from response schemas. Seed
+// is mixed with the request method and path, so a request always gets the
+// same body. MaxDepth limits nesting; ArrayLength sets the number of array
+// items (the maximum in fake mode), 0 keeps the mode's default.
+type GeneratorConfig struct {
+	Mode        GeneratorMode
+	Seed        string
+	MaxDepth    int
+	ArrayLength int
+}
+
+// PaginationConfig pages list responses of operations that declare paging
+// query params. Total is the number of items of generated lists.
+type PaginationConfig struct {
+	Enabled bool
+	Total   int
+}
+
+// MountConfig describes one spec served under a URL prefix.
+type MountConfig struct {
+	Prefix     string
+	SpecPath   string
+	SamplesDir string
+}
+
 type Config struct {
-	ServerPort     string
-	SpecPath       string
-	SamplesDir     string
-	LogLevel       string
-	RunningEnv     RunningEnv
-	FallbackMode   FallbackMode
-	DebugRoutes    bool
-	ValidationMode ValidationMode
-	Layout         LayoutMode
+	ServerPort         string
+	SpecPath           string
+	SamplesDir         string
+	LogLevel           string
+	RunningEnv         RunningEnv
+	FallbackMode       FallbackMode
+	DebugRoutes        bool
+	ValidationMode     ValidationMode
+	SpecValidation     SpecValidationMode
+	ResponseValidation ResponseValidationMode
+	Negotiation        NegotiationMode
+	ErrorFormat        ErrorFormat
+	Layout             LayoutMode
+	BasePathMode       BasePathMode
+	Mounts             []MountConfig
+	Watch              WatchConfig
+	Security           SecurityConfig
+	Generator          GeneratorConfig
+	Pagination         PaginationConfig
+	Templating         bool
 
 	Scenario ScenarioConfig
 }
@@ -64,15 +161,45 @@ func initConfig() Config {
 	_ = godotenv.Load()
 
 	return Config{
-		ServerPort:     utils.GetEnv("SERVER_PORT", "8086"),
-		SpecPath:       utils.GetEnv("SPEC_PATH", "/work/swagger.json"),
-		SamplesDir:     utils.GetEnv("SAMPLES_DIR", "/work/sample"),
-		LogLevel:       utils.GetEnv("LOG_LEVEL", "info"),
-		RunningEnv:     RunningEnv(utils.GetEnv("RUNNING_ENV", "docker")),
-		ValidationMode: ValidationMode(utils.GetEnv("VALIDATION_MODE", "required")),
-		FallbackMode:   FallbackMode(utils.GetEnv("FALLBACK_MODE", "openapi_examples")),
-		DebugRoutes:    utils.GetEnvAsBool("DEBUG_ROUTES", false),
-		Layout:         LayoutMode(utils.GetEnv("LAYOUT_MODE", "auto")),
+		ServerPort:         utils.GetEnv("SERVER_PORT", "8086"),
+		SpecPath:           utils.GetEnv("SPEC_PATH", "/work/swagger.json"),
+		SamplesDir:         utils.GetEnv("SAMPLES_DIR", "/work/sample"),
+		LogLevel:           utils.GetEnv("LOG_LEVEL", "info"),
+		RunningEnv:         RunningEnv(utils.GetEnv("RUNNING_ENV", "docker")),
+		ValidationMode:     ValidationMode(utils.GetEnv("VALIDATION_MODE", "required")),
+		SpecValidation:     SpecValidationMode(utils.GetEnv("SPEC_VALIDATION", "lenient")),
+		ResponseValidation: ResponseValidationMode(utils.GetEnv("RESPONSE_VALIDATION", "none")),
+		Negotiation:        NegotiationMode(utils.GetEnv("NEGOTIATION_MODE", "strict")),
+		ErrorFormat:        ErrorFormat(utils.GetEnv("ERROR_FORMAT", "problem")),
+		FallbackMode:       FallbackMode(utils.GetEnv("FALLBACK_MODE", "openapi_examples")),
+		DebugRoutes:        utils.GetEnvAsBool("DEBUG_ROUTES", false),
+		Layout:             LayoutMode(utils.GetEnv("LAYOUT_MODE", "auto")),
+		BasePathMode:       BasePathMode(utils.GetEnv("BASE_PATH_MODE", "auto")),
+		Mounts:             parseMounts(utils.GetEnv("MOUNTS", "")),
+
+		Watch: WatchConfig{
+			Enabled:    utils.GetEnvAsBool("WATCH_ENABLED", false),
+			IntervalMs: utils.GetEnvAsInt("WATCH_INTERVAL_MS", 1000),
+		},
+
+		Security: SecurityConfig{
+			Enabled:     utils.GetEnvAsBool("SECURITY_ENABLED", false),
+			Credentials: parseCredentials(utils.GetEnv("SECURITY_CREDENTIALS", "")),
+		},
+
+		Generator: GeneratorConfig{
+			Mode:        GeneratorMode(utils.GetEnv("GENERATOR_MODE", "static")),
+			Seed:        utils.GetEnv("GENERATOR_SEED", ""),
+			MaxDepth:    utils.GetEnvAsInt("GENERATOR_MAX_DEPTH", 6),
+			ArrayLength: utils.GetEnvAsInt("GENERATOR_ARRAY_LENGTH", 0),
+		},
+
+		Pagination: PaginationConfig{
+			Enabled: utils.GetEnvAsBool("PAGINATION_ENABLED", false),
+			Total:   utils.GetEnvAsInt("PAGINATION_TOTAL", 50),
+		},
+
+		Templating: utils.GetEnvAsBool("TEMPLATING_ENABLED", false),
 
 		Scenario: ScenarioConfig{
 			Enabled:  utils.GetEnvAsBool("SCENARIO_ENABLED", true),
@@ -80,3 +207,46 @@ func initConfig() Config {
 		},
 	}
 }
+
+// parseMounts parses MOUNTS entries of the form
+// "<prefix>=<specPath>,<samplesDir>" separated by ";".
+// Incomplete entries are kept so the server can report them.
+func parseMounts(raw string) []MountConfig {
+	var out []MountConfig
+	for _, entry := range strings.Split(raw, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		prefix, rest, _ := strings.Cut(entry, "=")
+		specPath, samplesDir, _ := strings.Cut(rest, ",")
+
+		out = append(out, MountConfig{
+			Prefix:     strings.TrimSpace(prefix),
+			SpecPath:   strings.TrimSpace(specPath),
+			SamplesDir: strings.TrimSpace(samplesDir),
+		})
+	}
+	return out
+}
+
+// parseCredentials parses SECURITY_CREDENTIALS entries of the form
+// "<scheme>=<credential>|<credential>" separated by ";".
+func parseCredentials(raw string) map[string][]string {
+	out := map[string][]string{}
+	for _, entry := range strings.Split(raw, ";") {
+		scheme, rest, ok := strings.Cut(entry, "=")
+		scheme = strings.TrimSpace(scheme)
+		if !ok || scheme == "" {
+			continue
+		}
+
+		for _, cred := range strings.Split(rest, "|") {
+			if cred = strings.TrimSpace(cred); cred != "" {
+				out[scheme] = append(out[scheme], cred)
+			}
+		}
+	}
+	return out
+}
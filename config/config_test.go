@@ -6,6 +6,7 @@ package config
 
 import (
 	"os"
+	"reflect"
 	"testing"
 )
 
@@ -21,6 +22,23 @@ func TestInitConfig_Defaults_AllFields(t *testing.T) {
 	_ = os.Unsetenv("LAYOUT_MODE")
 	_ = os.Unsetenv("SCENARIO_ENABLED")
 	_ = os.Unsetenv("SCENARIO_FILENAME")
+	_ = os.Unsetenv("MOUNTS")
+	_ = os.Unsetenv("SPEC_VALIDATION")
+	_ = os.Unsetenv("NEGOTIATION_MODE")
+	_ = os.Unsetenv("RESPONSE_VALIDATION")
+	_ = os.Unsetenv("ERROR_FORMAT")
+	_ = os.Unsetenv("SECURITY_ENABLED")
+	_ = os.Unsetenv("SECURITY_CREDENTIALS")
+	_ = os.Unsetenv("BASE_PATH_MODE")
+	_ = os.Unsetenv("WATCH_ENABLED")
+	_ = os.Unsetenv("WATCH_INTERVAL_MS")
+	_ = os.Unsetenv("GENERATOR_MODE")
+	_ = os.Unsetenv("GENERATOR_SEED")
+	_ = os.Unsetenv("GENERATOR_MAX_DEPTH")
+	_ = os.Unsetenv("GENERATOR_ARRAY_LENGTH")
+	_ = os.Unsetenv("PAGINATION_ENABLED")
+	_ = os.Unsetenv("PAGINATION_TOTAL")
+	_ = os.Unsetenv("TEMPLATING_ENABLED")
 
 	cfg := initConfig()
 
@@ -51,6 +69,39 @@ func TestInitConfig_Defaults_AllFields(t *testing.T) {
 	if cfg.Layout != LayoutAuto {
 		t.Fatalf("Layout: expected %q, got %q", LayoutAuto, cfg.Layout)
 	}
+	if cfg.SpecValidation != SpecValidationLenient {
+		t.Fatalf("SpecValidation: expected %q, got %q", SpecValidationLenient, cfg.SpecValidation)
+	}
+	if cfg.Negotiation != NegotiationStrict {
+		t.Fatalf("Negotiation: expected %q, got %q", NegotiationStrict, cfg.Negotiation)
+	}
+	if cfg.ResponseValidation != ResponseValidationNone {
+		t.Fatalf("ResponseValidation: expected %q, got %q", ResponseValidationNone, cfg.ResponseValidation)
+	}
+	if cfg.ErrorFormat != ErrorFormatProblem {
+		t.Fatalf("ErrorFormat: expected %q, got %q", ErrorFormatProblem, cfg.ErrorFormat)
+	}
+	if cfg.Security.Enabled || len(cfg.Security.Credentials) != 0 {
+		t.Fatalf("Security: expected disabled without credentials, got %#v", cfg.Security)
+	}
+	if cfg.BasePathMode != BasePathAuto {
+		t.Fatalf("BasePathMode: expected %q, got %q", BasePathAuto, cfg.BasePathMode)
+	}
+	if len(cfg.Mounts) != 0 {
+		t.Fatalf("Mounts: expected none, got %#v", cfg.Mounts)
+	}
+	if cfg.Watch.Enabled != false {
+		t.Fatalf("Watch.Enabled: expected %v, got %v", false, cfg.Watch.Enabled)
+	}
+	if cfg.Watch.IntervalMs != 1000 {
+		t.Fatalf("Watch.IntervalMs: expected %d, got %d", 1000, cfg.Watch.IntervalMs)
+	}
+	if cfg.Pagination.Enabled || cfg.Pagination.Total != 50 {
+		t.Fatalf("Pagination: expected disabled with total 50, got %+v", cfg.Pagination)
+	}
+	if cfg.Templating {
+		t.Fatalf("Templating: expected disabled")
+	}
 
 	if cfg.Scenario.Enabled != true {
 		t.Fatalf("Scenario.Enabled: expected %v, got %v", true, cfg.Scenario.Enabled)
@@ -73,6 +124,20 @@ func TestInitConfig_Overrides_AllFields(t *testing.T) {
 
 	t.Setenv("SCENARIO_ENABLED", "false")
 	t.Setenv("SCENARIO_FILENAME", "my-scenario.json")
+	t.Setenv("WATCH_ENABLED", "true")
+	t.Setenv("BASE_PATH_MODE", "required")
+	t.Setenv("SPEC_VALIDATION", "strict")
+	t.Setenv("NEGOTIATION_MODE", "lenient")
+	t.Setenv("RESPONSE_VALIDATION", "fail")
+	t.Setenv("ERROR_FORMAT", "spec")
+	t.Setenv("WATCH_INTERVAL_MS", "250")
+	t.Setenv("GENERATOR_MODE", "fake")
+	t.Setenv("GENERATOR_SEED", "ci-1")
+	t.Setenv("GENERATOR_MAX_DEPTH", "3")
+	t.Setenv("GENERATOR_ARRAY_LENGTH", "10")
+	t.Setenv("PAGINATION_ENABLED", "true")
+	t.Setenv("PAGINATION_TOTAL", "120")
+	t.Setenv("TEMPLATING_ENABLED", "true")
 
 	cfg := initConfig()
 
@@ -110,6 +175,45 @@ func TestInitConfig_Overrides_AllFields(t *testing.T) {
 	if cfg.Scenario.Filename != "my-scenario.json" {
 		t.Fatalf("Scenario.Filename: expected %q, got %q", "my-scenario.json", cfg.Scenario.Filename)
 	}
+	if cfg.SpecValidation != SpecValidationStrict {
+		t.Fatalf("SpecValidation: expected %q, got %q", SpecValidationStrict, cfg.SpecValidation)
+	}
+	if cfg.Negotiation != NegotiationLenient {
+		t.Fatalf("Negotiation: expected %q, got %q", NegotiationLenient, cfg.Negotiation)
+	}
+	if cfg.ResponseValidation != ResponseValidationFail {
+		t.Fatalf("ResponseValidation: expected %q, got %q", ResponseValidationFail, cfg.ResponseValidation)
+	}
+	if cfg.ErrorFormat != ErrorFormatSpec {
+		t.Fatalf("ErrorFormat: expected %q, got %q", ErrorFormatSpec, cfg.ErrorFormat)
+	}
+	if cfg.BasePathMode != BasePathRequired {
+		t.Fatalf("BasePathMode: expected %q, got %q", BasePathRequired, cfg.BasePathMode)
+	}
+	if cfg.Watch.Enabled != true {
+		t.Fatalf("Watch.Enabled: expected %v, got %v", true, cfg.Watch.Enabled)
+	}
+	if cfg.Watch.IntervalMs != 250 {
+		t.Fatalf("Watch.IntervalMs: expected %d, got %d", 250, cfg.Watch.IntervalMs)
+	}
+	if cfg.Generator.Mode != GeneratorFake {
+		t.Fatalf("Generator.Mode: expected %q, got %q", GeneratorFake, cfg.Generator.Mode)
+	}
+	if cfg.Generator.Seed != "ci-1" {
+		t.Fatalf("Generator.Seed: expected %q, got %q", "ci-1", cfg.Generator.Seed)
+	}
+	if cfg.Generator.MaxDepth != 3 {
+		t.Fatalf("Generator.MaxDepth: expected %d, got %d", 3, cfg.Generator.MaxDepth)
+	}
+	if cfg.Generator.ArrayLength != 10 {
+		t.Fatalf("Generator.ArrayLength: expected %d, got %d", 10, cfg.Generator.ArrayLength)
+	}
+	if !cfg.Pagination.Enabled || cfg.Pagination.Total != 120 {
+		t.Fatalf("Pagination: expected enabled with total 120, got %+v", cfg.Pagination)
+	}
+	if !cfg.Templating {
+		t.Fatalf("Templating: expected enabled")
+	}
 }
 
 func TestInitConfig_BoolParsing_DebugRoutesVariants(t *testing.T) {
@@ -163,3 +267,42 @@ func TestInitConfig_BoolParsing_ScenarioEnabledVariants(t *testing.T) {
 		})
 	}
 }
+
+func TestInitConfig_Mounts(t *testing.T) {
+	t.Setenv("MOUNTS", " /openvasd=/work/openvasd/swagger.json,/work/openvasd/sample ; /notus = /work/notus/openapi.yaml , /work/notus/sample ;; /broken")
+
+	cfg := initConfig()
+
+	want := []MountConfig{
+		{Prefix: "/openvasd", SpecPath: "/work/openvasd/swagger.json", SamplesDir: "/work/openvasd/sample"},
+		{Prefix: "/notus", SpecPath: "/work/notus/openapi.yaml", SamplesDir: "/work/notus/sample"},
+		{Prefix: "/broken"},
+	}
+	if len(cfg.Mounts) != len(want) {
+		t.Fatalf("Mounts: expected %d entries, got %#v", len(want), cfg.Mounts)
+	}
+	for i := range want {
+		if cfg.Mounts[i] != want[i] {
+			t.Fatalf("Mounts[%d]: expected %#v, got %#v", i, want[i], cfg.Mounts[i])
+		}
+	}
+}
+
+func TestInitConfig_SecurityCredentials(t *testing.T) {
+	t.Setenv("SECURITY_ENABLED", "true")
+	t.Setenv("SECURITY_CREDENTIALS", " apiKey = k1 | k2 ; basic=admin:secret;; oauth=tok(read:items write:items) ;broken; empty=")
+
+	cfg := initConfig()
+
+	if !cfg.Security.Enabled {
+		t.Fatalf("Security.Enabled: expected true")
+	}
+	want := map[string][]string{
+		"apiKey": {"k1", "k2"},
+		"basic":  {"admin:secret"},
+		"oauth":  {"tok(read:items write:items)"},
+	}
+	if !reflect.DeepEqual(cfg.Security.Credentials, want) {
+		t.Fatalf("Security.Credentials: expected %#v, got %#v", want, cfg.Security.Credentials)
+	}
+}
@@ -0,0 +1,224 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openapi
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ContentFailure describes a request whose media types do not fit the
+// matched operation.
+type ContentFailure struct {
+	// Status is 415 for an unsupported Content-Type and 406 for an Accept
+	// header that matches none of the response media types.
+	Status    int
+	Reason    string
+	Supported []string
+}
+
+func (f *ContentFailure) Error() string {
+	return fmt.Sprintf("%s (supported: %s)", f.Reason, strings.Join(f.Supported, ", "))
+}
+
+// CheckContentType checks the request Content-Type against the request
+// body media types of the matched operation. It returns a 415 failure for
+// a media type the operation does not accept.
+func (v *Validator) CheckContentType(r *http.Request, match *RouteMatch) *ContentFailure {
+	op := v.spec.FindOperation(match.Route.Swagger, match.Route.Method)
+	ct := r.Header.Get("Content-Type")
+	if op == nil || ct == "" || op.RequestBody == nil || op.RequestBody.Value == nil {
+		return nil
+	}
+
+	supported := sortedMediaTypes(op.RequestBody.Value.Content)
+	if len(supported) == 0 || mediaTypeSupported(ct, supported) {
+		return nil
+	}
+	return &ContentFailure{
+		Status:    http.StatusUnsupportedMediaType,
+		Reason:    fmt.Sprintf("content type %s is not supported", ct),
+		Supported: supported,
+	}
+}
+
+// NegotiateAccept checks the Accept header against the media types of the
+// response that is served: the one declared for status (or its range),
+// the one holding the named example, or the best response. Error statuses
+// the operation does not declare use "default"; success statuses use the
+// best response, as samples are 200 unless they say otherwise. It returns
+// the response media type to use, or "" when the response declares none.
+func (v *Validator) NegotiateAccept(r *http.Request, match *RouteMatch, status int, example string) (string, *ContentFailure) {
+	op := v.spec.FindOperation(match.Route.Swagger, match.Route.Method)
+	if op == nil || op.Responses == nil {
+		return "", nil
+	}
+
+	var resp *openapi3.ResponseRef
+	if status != 0 {
+		resp = op.Responses.Status(status)
+	}
+	switch {
+	case resp != nil:
+	case status >= 400:
+		resp = op.Responses.Default()
+	default:
+		resp = preferredResponse(op.Responses, ExampleOptions{Name: example})
+	}
+	if resp == nil || resp.Value == nil || len(resp.Value.Content) == 0 {
+		return "", nil
+	}
+	offered := sortedMediaTypes(resp.Value.Content)
+
+	accept := strings.Join(r.Header.Values("Accept"), ",")
+	mt, ok := NegotiateMediaType(accept, offered)
+	if !ok {
+		return "", &ContentFailure{
+			Status:    http.StatusNotAcceptable,
+			Reason:    fmt.Sprintf("none of the accepted media types %s can be produced", accept),
+			Supported: offered,
+		}
+	}
+	return mt, nil
+}
+
+// NegotiateMediaType picks the offered media type that best fits the Accept
+// header, honouring q-values and specificity. An empty header accepts the
+// first concrete offer. Offers may be ranges such as "*/*"; a concrete
+// accepted type is returned for them.
+func NegotiateMediaType(accept string, offered []string) (string, bool) {
+	if strings.TrimSpace(accept) == "" {
+		for _, o := range offered {
+			if !isMediaRange(o) {
+				return o, true
+			}
+		}
+		return "", true
+	}
+
+	for _, ar := range parseAccept(accept) {
+		if ar.q <= 0 {
+			continue
+		}
+		for _, o := range offered {
+			if !mediaRangeMatches(ar.mediaType, o) && !mediaRangeMatches(o, ar.mediaType) {
+				continue
+			}
+			if !isMediaRange(o) {
+				return o, true
+			}
+			if !isMediaRange(ar.mediaType) {
+				return ar.mediaType, true
+			}
+			return "", true
+		}
+	}
+	return "", false
+}
+
+type acceptRange struct {
+	mediaType string
+	q         float64
+}
+
+// parseAccept returns the media ranges of an Accept header ordered by
+// q-value, then by specificity.
+func parseAccept(header string) []acceptRange {
+	var out []acceptRange
+	for _, part := range strings.Split(header, ",") {
+		mt, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if raw, ok := params["q"]; ok {
+			if f, err := strconv.ParseFloat(raw, 64); err == nil {
+				q = f
+			}
+		}
+		out = append(out, acceptRange{mediaType: mt, q: q})
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].q != out[j].q {
+			return out[i].q > out[j].q
+		}
+		return specificity(out[i].mediaType) > specificity(out[j].mediaType)
+	})
+	return out
+}
+
+// mediaTypeSupported reports whether the Content-Type value ct fits one of
+// the supported media types or ranges.
+func mediaTypeSupported(ct string, supported []string) bool {
+	mt, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return false
+	}
+	for _, s := range supported {
+		if mediaRangeMatches(s, mt) {
+			return true
+		}
+	}
+	return false
+}
+
+// mediaRangeMatches reports whether the media type mt falls into rng, which
+// may be "*/*", "type/*" or a concrete type. Parameters are ignored.
+func mediaRangeMatches(rng, mt string) bool {
+	rng = baseMediaType(rng)
+	mt = baseMediaType(mt)
+	if rng == "*/*" || rng == mt {
+		return true
+	}
+	rType, rSub, _ := strings.Cut(rng, "/")
+	mType, _, _ := strings.Cut(mt, "/")
+	return rSub == "*" && rType == mType
+}
+
+func isMediaRange(mt string) bool {
+	return strings.HasSuffix(baseMediaType(mt), "/*")
+}
+
+func specificity(mt string) int {
+	switch {
+	case mt == "*/*":
+		return 0
+	case strings.HasSuffix(mt, "/*"):
+		return 1
+	default:
+		return 2
+	}
+}
+
+func baseMediaType(mt string) string {
+	mt, _, _ = strings.Cut(mt, ";")
+	return strings.ToLower(strings.TrimSpace(mt))
+}
+
+// sortedMediaTypes returns the media types of content, JSON first and
+// ranges last.
+func sortedMediaTypes[V any](content map[string]V) []string {
+	out := make([]string, 0, len(content))
+	for mt := range content {
+		out = append(out, mt)
+	}
+	rank := func(mt string) int {
+		if isMediaRange(mt) {
+			return 3
+		}
+		return mimeRank(mt)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return rank(out[i]) < rank(out[j]) || (rank(out[i]) == rank(out[j]) && out[i] < out[j])
+	})
+	return out
+}
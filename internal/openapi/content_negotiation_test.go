@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openapi
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestNegotiateMediaType(t *testing.T) {
+	offered := []string{"application/json", "application/xml", "text/csv"}
+
+	cases := []struct {
+		accept string
+		want   string
+		ok     bool
+	}{
+		{"", "application/json", true},
+		{"*/*", "application/json", true},
+		{"application/xml", "application/xml", true},
+		{"text/*", "text/csv", true},
+		{"application/xml;q=0.5, text/csv", "text/csv", true},
+		{"text/html, application/*;q=0.1", "application/json", true},
+		{"text/csv;q=0, application/xml;q=0.2", "application/xml", true},
+		{"text/html", "", false},
+		{"text/csv;q=0", "", false},
+	}
+	for _, tc := range cases {
+		got, ok := NegotiateMediaType(tc.accept, offered)
+		if got != tc.want || ok != tc.ok {
+			t.Fatalf("%q: expected %q/%v, got %q/%v", tc.accept, tc.want, tc.ok, got, ok)
+		}
+	}
+}
+
+func TestNegotiateMediaType_RangeOffered(t *testing.T) {
+	if got, ok := NegotiateMediaType("text/plain", []string{"*/*"}); !ok || got != "text/plain" {
+		t.Fatalf("expected accepted type for range offer, got %q/%v", got, ok)
+	}
+	if got, ok := NegotiateMediaType("", []string{"*/*"}); !ok || got != "" {
+		t.Fatalf("expected no preference, got %q/%v", got, ok)
+	}
+}
+
+const contentSpec = `{
+  "openapi":"3.0.3",
+  "info":{"title":"t","version":"1"},
+  "paths":{
+    "/items":{"post":{
+      "requestBody":{"content":{
+        "application/json":{"schema":{"type":"object"}},
+        "text/*":{"schema":{"type":"string"}}
+      }},
+      "responses":{
+        "201":{"description":"created","content":{
+          "application/json":{"example":{"ok":true}},
+          "application/xml":{"example":"<ok/>"}
+        }},
+        "202":{"description":"accepted","content":{
+          "text/plain":{"examples":{"queued":{"value":"queued"}}}
+        }},
+        "default":{"description":"error","content":{"application/problem+json":{}}}
+      }
+    }},
+    "/empty":{"delete":{"responses":{"204":{"description":"gone"}}}}
+  }
+}`
+
+func TestCheckContentType(t *testing.T) {
+	v, router := newTestValidator(t, contentSpec)
+
+	for _, ct := range []string{"", "application/json; charset=utf-8", "text/plain"} {
+		r, m := routedRequest(t, router, http.MethodPost, "/items", "x", map[string]string{"Content-Type": ct})
+		if f := v.CheckContentType(r, m); f != nil {
+			t.Fatalf("%q: unexpected failure %v", ct, f)
+		}
+	}
+
+	r, m := routedRequest(t, router, http.MethodPost, "/items", "x", map[string]string{"Content-Type": "application/xml", "Accept": "text/html"})
+	f := v.CheckContentType(r, m)
+	if f == nil || f.Status != http.StatusUnsupportedMediaType {
+		t.Fatalf("expected 415, got %#v", f)
+	}
+	if strings.Join(f.Supported, ",") != "application/json,text/*" {
+		t.Fatalf("unexpected supported types %v", f.Supported)
+	}
+}
+
+func TestNegotiateAccept(t *testing.T) {
+	v, router := newTestValidator(t, contentSpec)
+
+	cases := []struct {
+		accept    string
+		status    int
+		example   string
+		mediaType string
+		failure   int
+	}{
+		{"", 0, "", "application/json", 0},
+		{"application/xml", 0, "", "application/xml", 0},
+		{"text/html, */*;q=0.1", 0, "", "application/json", 0},
+		{"text/html", 0, "", "", http.StatusNotAcceptable},
+		// a sample's 200 is not declared: the best response is served
+		{"application/xml", 200, "", "application/xml", 0},
+		{"text/plain", 202, "", "text/plain", 0},
+		{"application/json", 202, "", "", http.StatusNotAcceptable},
+		{"text/plain", 0, "queued", "text/plain", 0},
+		{"", 404, "", "application/problem+json", 0},
+		{"application/json", 404, "", "", http.StatusNotAcceptable},
+	}
+	for _, tc := range cases {
+		r, m := routedRequest(t, router, http.MethodPost, "/items", "x", map[string]string{"Accept": tc.accept})
+		mt, f := v.NegotiateAccept(r, m, tc.status, tc.example)
+		if mt != tc.mediaType {
+			t.Fatalf("%q %d %q: expected media type %q, got %q", tc.accept, tc.status, tc.example, tc.mediaType, mt)
+		}
+		switch {
+		case tc.failure == 0 && f != nil:
+			t.Fatalf("%q %d: unexpected failure %v", tc.accept, tc.status, f)
+		case tc.failure != 0 && (f == nil || f.Status != tc.failure):
+			t.Fatalf("%q %d: expected %d, got %#v", tc.accept, tc.status, tc.failure, f)
+		}
+	}
+}
+
+func TestNegotiateAccept_NoResponseContent(t *testing.T) {
+	v, router := newTestValidator(t, contentSpec)
+
+	r, m := routedRequest(t, router, http.MethodDelete, "/empty", "x", map[string]string{"Accept": "text/html"})
+	if mt, f := v.NegotiateAccept(r, m, 0, ""); mt != "" || f != nil {
+		t.Fatalf("expected no negotiation, got %q %v", mt, f)
+	}
+}
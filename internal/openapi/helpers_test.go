@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+// newTestProvider loads spec, JSON or YAML, from a temporary spec file.
+func newTestProvider(t *testing.T, spec string) *SpecProvider {
+	t.Helper()
+
+	p := filepath.Join(t.TempDir(), "spec")
+	if err := os.WriteFile(p, []byte(spec), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	provider, err := NewSpecProvider(p, logrus.New())
+	if err != nil {
+		t.Fatalf("NewSpecProvider: %v", err)
+	}
+	return provider.(*SpecProvider)
+}
+
+// newTestValidator returns the validator and router of spec.
+func newTestValidator(t *testing.T, spec string) (IValidator, IRouterProvider) {
+	t.Helper()
+
+	provider := newTestProvider(t, spec)
+	return NewValidator(provider), NewRouterProvider(provider.GetSpec(), RouterConfig{})
+}
+
+// routedRequest builds a request with body and header and finds its route.
+// Headers with an empty value are left out.
+func routedRequest(t *testing.T, router IRouterProvider, method, target, body string, header map[string]string) (*http.Request, *RouteMatch) {
+	t.Helper()
+
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	for k, v := range header {
+		if v != "" {
+			r.Header.Set(k, v)
+		}
+	}
+	m, err := router.FindRoute(r.Method, r.URL.EscapedPath())
+	if err != nil {
+		t.Fatalf("FindRoute: %v", err)
+	}
+	return r, m
+}
@@ -11,17 +11,24 @@ import (
 )
 
 type IRouterProvider interface {
-	FindRoute(method, path string) *Route
+	FindRoute(method, path string) (*RouteMatch, error)
 	GetRoutes() []Route
 }
 
 type ISpecProvider interface {
-	TryGetExampleBody(swaggerPath, method string) ([]byte, bool)
+	TryGetExample(swaggerPath, method string, opts ExampleOptions) (*Example, bool)
+	TryGetStatusExample(swaggerPath, method string, status int, mediaType string) (*Example, bool)
 	FindOperation(swaggerPath, method string) *openapi3.Operation
+	FindPaging(swaggerPath, method string) *Paging
 	GetSpec() *Spec
 }
 
 type IValidator interface {
 	HasRequiredBodyParam(swaggerPath, method string) bool
 	IsEmptyBody(r *http.Request) (bool, error)
+	ValidateRequest(r *http.Request, match *RouteMatch) []Violation
+	ValidateResponse(r *http.Request, match *RouteMatch, status int, header http.Header, body []byte) []Violation
+	CheckContentType(r *http.Request, match *RouteMatch) *ContentFailure
+	NegotiateAccept(r *http.Request, match *RouteMatch, status int, example string) (string, *ContentFailure)
+	CheckSecurity(r *http.Request, match *RouteMatch, credentials map[string][]string) *SecurityFailure
 }
@@ -0,0 +1,281 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openapi
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Minimal valid files served for binary media types.
+var (
+	placeholderPNG = mustDecodeBase64("iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR42mNkYPhfDwAChwGA60e6kgAAAABJRU5ErkJggg==")
+	placeholderGIF = mustDecodeBase64("R0lGODlhAQABAIAAAAAAAP///yH5BAEAAAAALAAAAAABAAEAAAIBRAA7")
+	placeholderPDF = []byte("%PDF-1.4\n" +
+		"1 0 obj<</Type/Catalog/Pages 2 0 R>>endobj\n" +
+		"2 0 obj<</Type/Pages/Kids[3 0 R]/Count 1>>endobj\n" +
+		"3 0 obj<</Type/Page/Parent 2 0 R/MediaBox[0 0 612 792]>>endobj\n" +
+		"trailer<</Root 1 0 R>>\n" +
+		"%%EOF\n")
+	placeholderZIP = append([]byte("PK\x05\x06"), make([]byte, 18)...) // empty archive
+)
+
+func mustDecodeBase64(s string) []byte {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// encodeBody encodes a body value for mediaType: JSON, XML shaped by the
+// xml objects of the schema ref, CSV or plain text. Strings of non-JSON
+// media types are used as they are; binary media types get a placeholder.
+func encodeBody(v any, ref *openapi3.SchemaRef, mediaType string) ([]byte, bool) {
+	if mediaType == "" || IsJSONMediaType(mediaType) {
+		b, err := json.Marshal(v)
+		return b, err == nil
+	}
+	if s, ok := v.(string); ok {
+		return []byte(s), true
+	}
+
+	switch {
+	case isXMLMediaType(mediaType):
+		return xmlBody(v, ref), true
+	case baseMediaType(mediaType) == "text/csv":
+		return csvBody(v)
+	case isTextMediaType(mediaType):
+		switch v.(type) {
+		case map[string]any, []any:
+			b, err := json.Marshal(v)
+			return b, err == nil
+		}
+		return []byte(simpleStyle(v)), true
+	}
+	return binaryPlaceholder(mediaType), true
+}
+
+func isXMLMediaType(mediaType string) bool {
+	mt := baseMediaType(mediaType)
+	return mt == "application/xml" || mt == "text/xml" || strings.HasSuffix(mt, "+xml")
+}
+
+func isTextMediaType(mediaType string) bool {
+	return strings.HasPrefix(baseMediaType(mediaType), "text/")
+}
+
+// isBinaryMediaType reports whether bodies of mediaType are opaque bytes,
+// such as application/pdf or image/png.
+func isBinaryMediaType(mediaType string) bool {
+	return mediaType != "" && !IsJSONMediaType(mediaType) && !isXMLMediaType(mediaType) && !isTextMediaType(mediaType)
+}
+
+// binaryPlaceholder returns a minimal valid file for well-known binary media
+// types and a short marker for the others.
+func binaryPlaceholder(mediaType string) []byte {
+	switch mt := baseMediaType(mediaType); mt {
+	case "application/pdf":
+		return placeholderPDF
+	case "image/png":
+		return placeholderPNG
+	case "image/gif":
+		return placeholderGIF
+	case "application/zip":
+		return placeholderZIP
+	default:
+		return []byte(fmt.Sprintf("placeholder %s content\n", mt))
+	}
+}
+
+// csvBody writes an array of objects as a CSV table with a header row of
+// the sorted property names. A single object is one row; scalars are one
+// column without a header.
+func csvBody(v any) ([]byte, bool) {
+	rows, ok := v.([]any)
+	if !ok {
+		rows = []any{v}
+	}
+
+	seen := map[string]bool{}
+	for _, row := range rows {
+		if m, ok := row.(map[string]any); ok {
+			for k := range m {
+				seen[k] = true
+			}
+		}
+	}
+	columns := sortedKeys(seen)
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if len(columns) > 0 {
+		_ = w.Write(columns)
+	}
+	for _, row := range rows {
+		m, ok := row.(map[string]any)
+		if len(columns) == 0 || !ok {
+			_ = w.Write([]string{simpleStyle(row)})
+			continue
+		}
+		record := make([]string, len(columns))
+		for i, c := range columns {
+			record[i] = simpleStyle(m[c])
+		}
+		_ = w.Write(record)
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error() == nil
+}
+
+// xmlBody writes v as an XML document. Element names, attributes,
+// namespaces and array wrapping follow the xml objects of the schema; the
+// root element is named after the schema, or "root".
+func xmlBody(v any, ref *openapi3.SchemaRef) []byte {
+	name := "root"
+	if ref != nil && ref.Ref != "" {
+		name = refName(ref.Ref)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	items, ok := v.([]any)
+	if !ok {
+		writeXMLElement(&buf, name, v, ref)
+		return buf.Bytes()
+	}
+
+	// a document needs a single root element, so arrays are always
+	// wrapped; their items are named after the item schema
+	itemRef := itemsSchema(ref)
+	itemName := name
+	if itemRef != nil && itemRef.Ref != "" {
+		itemName = refName(itemRef.Ref)
+	}
+	tag := xmlName(ref, name)
+	buf.WriteString("<" + tag + xmlNamespace(xmlOf(ref)) + ">")
+	for _, item := range items {
+		writeXMLElement(&buf, itemName, item, itemRef)
+	}
+	buf.WriteString("</" + tag + ">")
+	return buf.Bytes()
+}
+
+// writeXMLElement writes v as the element for the property name. Unwrapped
+// arrays repeat the item element; wrapped ones enclose it.
+func writeXMLElement(buf *bytes.Buffer, name string, v any, ref *openapi3.SchemaRef) {
+	x := xmlOf(ref)
+	tag := xmlName(ref, name)
+
+	if items, ok := v.([]any); ok {
+		itemRef := itemsSchema(ref)
+		if x.Wrapped {
+			buf.WriteString("<" + tag + xmlNamespace(x) + ">")
+		}
+		for _, item := range items {
+			writeXMLElement(buf, name, item, itemRef)
+		}
+		if x.Wrapped {
+			buf.WriteString("</" + tag + ">")
+		}
+		return
+	}
+
+	buf.WriteString("<" + tag + xmlNamespace(x))
+	m, isObject := v.(map[string]any)
+	var children []string
+	for _, k := range sortedKeys(m) {
+		prop := propertySchema(ref, k, map[*openapi3.Schema]bool{})
+		if !xmlOf(prop).Attribute {
+			children = append(children, k)
+			continue
+		}
+		buf.WriteString(" " + xmlName(prop, k) + `="`)
+		_ = xml.EscapeText(buf, []byte(simpleStyle(m[k])))
+		buf.WriteString(`"`)
+	}
+
+	switch {
+	case isObject && len(children) > 0:
+		buf.WriteString(">")
+		for _, k := range children {
+			writeXMLElement(buf, k, m[k], propertySchema(ref, k, map[*openapi3.Schema]bool{}))
+		}
+	case !isObject && v != nil:
+		buf.WriteString(">")
+		_ = xml.EscapeText(buf, []byte(simpleStyle(v)))
+	default:
+		buf.WriteString("/>")
+		return
+	}
+	buf.WriteString("</" + tag + ">")
+}
+
+func xmlOf(ref *openapi3.SchemaRef) *openapi3.XML {
+	if ref == nil || ref.Value == nil || ref.Value.XML == nil {
+		return &openapi3.XML{}
+	}
+	return ref.Value.XML
+}
+
+// xmlName returns the element or attribute name of the property name,
+// replaced and prefixed by the xml object of its schema.
+func xmlName(ref *openapi3.SchemaRef, name string) string {
+	x := xmlOf(ref)
+	if x.Name != "" {
+		name = x.Name
+	}
+	if x.Prefix != "" {
+		name = x.Prefix + ":" + name
+	}
+	return name
+}
+
+func xmlNamespace(x *openapi3.XML) string {
+	switch {
+	case x.Namespace == "":
+		return ""
+	case x.Prefix != "":
+		return fmt.Sprintf(` xmlns:%s="%s"`, x.Prefix, x.Namespace)
+	default:
+		return fmt.Sprintf(` xmlns="%s"`, x.Namespace)
+	}
+}
+
+// propertySchema returns the schema of the named property of ref, looking
+// through allOf, oneOf and anyOf members.
+func propertySchema(ref *openapi3.SchemaRef, name string, seen map[*openapi3.Schema]bool) *openapi3.SchemaRef {
+	if ref == nil || ref.Value == nil || seen[ref.Value] {
+		return nil
+	}
+	s := ref.Value
+	seen[s] = true
+
+	if p := s.Properties[name]; p != nil {
+		return p
+	}
+	for _, members := range []openapi3.SchemaRefs{s.AllOf, s.OneOf, s.AnyOf} {
+		for _, m := range members {
+			if p := propertySchema(m, name, seen); p != nil {
+				return p
+			}
+		}
+	}
+	return s.AdditionalProperties.Schema
+}
+
+func itemsSchema(ref *openapi3.SchemaRef) *openapi3.SchemaRef {
+	if ref == nil || ref.Value == nil {
+		return nil
+	}
+	return ref.Value.Items
+}
@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openapi
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image/gif"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+const reportsSpec = `
+openapi: 3.0.3
+info: {title: reports, version: "1"}
+paths:
+  /reports/{id}:
+    get:
+      parameters: [{name: id, in: path, required: true, schema: {type: string}}]
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema: {$ref: "#/components/schemas/Report"}
+            application/xml:
+              schema: {$ref: "#/components/schemas/Report"}
+            text/csv:
+              schema:
+                type: array
+                items: {$ref: "#/components/schemas/Result"}
+            text/plain:
+              example: "report 1: 2 results"
+            application/pdf:
+              schema: {type: string, format: binary}
+            image/*:
+              schema: {type: string, format: binary}
+  /results:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/xml:
+              schema:
+                type: array
+                items: {$ref: "#/components/schemas/Result"}
+            text/csv:
+              example: |
+                host,severity
+                10.0.0.1,5
+components:
+  schemas:
+    Report:
+      type: object
+      xml: {name: report, namespace: "https://example.com/report"}
+      required: [id, owner, results, tags]
+      properties:
+        id: {type: string, enum: [r-1], xml: {attribute: true}}
+        owner: {type: string, enum: ["Ann & Bob"], xml: {name: Owner, prefix: gb}}
+        results:
+          type: array
+          xml: {wrapped: true}
+          items: {$ref: "#/components/schemas/Result"}
+        tags:
+          type: array
+          items: {type: string, enum: [nightly], xml: {name: tag}}
+    Result:
+      type: object
+      xml: {name: result}
+      required: [host, severity]
+      properties:
+        host: {type: string, enum: [10.0.0.1]}
+        severity: {type: number, enum: [5.5]}
+`
+
+func mediaExample(t *testing.T, p ISpecProvider, path, mediaType string) []byte {
+	t.Helper()
+
+	ex, ok := p.TryGetExample(path, "GET", ExampleOptions{MediaType: mediaType})
+	if !ok || ex.MediaType != mediaType {
+		t.Fatalf("%s: expected a %s example, got %#v", path, mediaType, ex)
+	}
+	return ex.Body
+}
+
+func TestTryGetExample_XMLFollowsSchemaXMLObjects(t *testing.T) {
+	p := newTestProvider(t, reportsSpec)
+
+	body := mediaExample(t, p, "/reports/{id}", "application/xml")
+	want := xml.Header +
+		`<report xmlns="https://example.com/report" id="r-1">` +
+		`<gb:Owner>Ann &amp; Bob</gb:Owner>` +
+		`<results><result><host>10.0.0.1</host><severity>5.5</severity></result></results>` +
+		`<tag>nightly</tag>` +
+		`</report>`
+	if string(body) != want {
+		t.Fatalf("unexpected xml:\n%s\nwant:\n%s", body, want)
+	}
+
+	body = mediaExample(t, p, "/results", "application/xml")
+	want = xml.Header + `<root><result><host>10.0.0.1</host><severity>5.5</severity></result></root>`
+	if string(body) != want {
+		t.Fatalf("unexpected xml array:\n%s", body)
+	}
+}
+
+func TestTryGetExample_TextAndCSV(t *testing.T) {
+	p := newTestProvider(t, reportsSpec)
+
+	if body := mediaExample(t, p, "/reports/{id}", "text/csv"); string(body) != "host,severity\n10.0.0.1,5.5\n" {
+		t.Fatalf("unexpected generated csv %q", body)
+	}
+	if body := mediaExample(t, p, "/results", "text/csv"); string(body) != "host,severity\n10.0.0.1,5\n" {
+		t.Fatalf("expected the csv example as it is, got %q", body)
+	}
+	if body := mediaExample(t, p, "/reports/{id}", "text/plain"); string(body) != "report 1: 2 results" {
+		t.Fatalf("unexpected text %q", body)
+	}
+}
+
+func TestTryGetExample_BinaryPlaceholders(t *testing.T) {
+	p := newTestProvider(t, reportsSpec)
+
+	if body := mediaExample(t, p, "/reports/{id}", "application/pdf"); !bytes.HasPrefix(body, []byte("%PDF-")) {
+		t.Fatalf("expected a pdf, got %q", body)
+	}
+	if _, err := png.Decode(bytes.NewReader(mediaExample(t, p, "/reports/{id}", "image/png"))); err != nil {
+		t.Fatalf("expected a png: %v", err)
+	}
+	if _, err := gif.Decode(bytes.NewReader(binaryPlaceholder("image/gif"))); err != nil {
+		t.Fatalf("expected a gif: %v", err)
+	}
+	if body := binaryPlaceholder("application/octet-stream"); !strings.Contains(string(body), "application/octet-stream") {
+		t.Fatalf("unexpected placeholder %q", body)
+	}
+}
+
+func TestCSVBody(t *testing.T) {
+	cases := []struct {
+		in   any
+		want string
+	}{
+		{[]any{map[string]any{"a": 1, "b": "x,y"}, map[string]any{"a": 2, "c": true}}, "a,b,c\n1,\"x,y\",\n2,,true\n"},
+		{map[string]any{"id": "1"}, "id\n1\n"},
+		{[]any{"one", float64(2)}, "one\n2\n"},
+	}
+	for _, tc := range cases {
+		if got, ok := csvBody(tc.in); !ok || string(got) != tc.want {
+			t.Fatalf("%v: expected %q, got %q", tc.in, tc.want, got)
+		}
+	}
+}
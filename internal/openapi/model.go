@@ -5,7 +5,7 @@
 package openapi
 
 import (
-	"regexp"
+	"github.com/ozgen/openapi-emulator/config"
 
 	"github.com/getkin/kin-openapi/openapi2"
 	"github.com/getkin/kin-openapi/openapi3"
@@ -14,8 +14,59 @@ import (
 type Route struct {
 	Method     string
 	Swagger    string
-	Regex      *regexp.Regexp
 	SampleFile string
+
+	segments []string
+	params   map[string]*openapi3.Schema // declared path param schemas by name
+}
+
+// RouteMatch is the result of matching a request path against the routes.
+// Path is the matched path as received, relative to the mount and base path;
+// Params holds the percent-decoded path parameters by name.
+type RouteMatch struct {
+	Route  *Route
+	Path   string
+	Params map[string]string
+}
+
+// Example is a response body taken from the spec with its media type.
+// Status is the code of the response it belongs to, the first code of a
+// range such as "2XX", or 0 for "default". Headers holds values for the
+// headers the response declares.
+type Example struct {
+	Status    int
+	MediaType string
+	Headers   map[string]string
+	Body      []byte
+}
+
+// ExampleOptions steers how an example is picked or generated.
+type ExampleOptions struct {
+	// MediaType is the negotiated response media type; "" means JSON.
+	MediaType string
+	// Branch selects the oneOf/anyOf branch of generated bodies: a
+	// discriminator value, a schema name or title, or an index.
+	Branch string
+	// Status and Name pick the response by status code and the named
+	// example, as with a "Prefer: code=404, example=notFound" header.
+	Status int
+	Name   string
+	// Random draws realistic values for generated bodies from a source
+	// seeded with Seed instead of using fixed placeholders.
+	Random bool
+	Seed   uint64
+	// MaxDepth is the nesting depth at which generation stops; 0 means 6.
+	MaxDepth int
+	// ArrayLength is the number of items of generated arrays, or the
+	// maximum for random values; 0 means 1, or 5 for random values.
+	ArrayLength int
+	// ListLength, if set, is the number of items of the paged list: a
+	// top-level array or the array property of a list envelope.
+	ListLength int
+}
+
+type RouterConfig struct {
+	BasePathMode config.BasePathMode
 }
 
 type Spec struct {
@@ -23,7 +74,9 @@ type Spec struct {
 	Doc2 *openapi2.T
 }
 
+// versionProbe reads the version fields as any value: YAML turns an
+// unquoted 2.0 into a number.
 type versionProbe struct {
-	Swagger string `json:"swagger"`
-	OpenAPI string `json:"openapi"`
+	Swagger any `json:"swagger"`
+	OpenAPI any `json:"openapi"`
 }
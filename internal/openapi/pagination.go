@@ -0,0 +1,463 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openapi
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// defaultPageLimit is the page size when the limit param declares no
+// default.
+const defaultPageLimit = 10
+
+// Normalized names (see pagingName) of paging query params, list
+// properties and envelope fields.
+var (
+	limitNames  = []string{"limit", "pagesize", "perpage", "size", "pagelimit", "maxresults"}
+	offsetNames = []string{"offset", "skip", "start", "startindex"}
+	pageNames   = []string{"page", "pagenumber", "pageno", "currentpage", "pageindex"}
+	cursorNames = []string{"cursor", "after", "pagetoken", "nexttoken", "continuationtoken", "startingafter"}
+
+	listNames = []string{"items", "data", "results", "result", "content", "records", "entries", "list", "values", "elements", "rows", "hits"}
+)
+
+// Paging describes the paging query params an operation declares and the
+// paging headers of its success response.
+type Paging struct {
+	// LimitParam, OffsetParam, PageParam and CursorParam are the names of
+	// the declared query params, "" for those not declared.
+	LimitParam  string
+	OffsetParam string
+	PageParam   string
+	CursorParam string
+	// DefaultLimit is the default of the limit param, or 10; MaxLimit is
+	// its maximum, 0 for none.
+	DefaultLimit int
+	MaxLimit     int
+	// FirstPage is the number of the first page: 1, or 0 when the page
+	// param allows 0.
+	FirstPage int
+	// LinkHeader and TotalHeader are the declared Link and total count
+	// (e.g. X-Total-Count) response headers, "" when not declared.
+	LinkHeader  string
+	TotalHeader string
+}
+
+// Page is the window of a list a request asks for.
+type Page struct {
+	Offset int
+	Limit  int
+}
+
+// FindPaging returns the paging params declared on the operation, or nil
+// when it declares none.
+func (p *SpecProvider) FindPaging(swaggerPath, method string) *Paging {
+	op := p.FindOperation(swaggerPath, method)
+	if op == nil {
+		return nil
+	}
+	item := p.spec.Doc3.Paths.Find(swaggerPath)
+
+	pg := &Paging{DefaultLimit: defaultPageLimit, FirstPage: 1}
+	for _, params := range []openapi3.Parameters{item.Parameters, op.Parameters} {
+		for _, ref := range params {
+			if ref == nil || ref.Value == nil || ref.Value.In != openapi3.ParameterInQuery {
+				continue
+			}
+			prm := ref.Value
+			var s *openapi3.Schema
+			if prm.Schema != nil {
+				s = prm.Schema.Value
+			}
+
+			switch name := pagingName(prm.Name); {
+			case slices.Contains(limitNames, name):
+				pg.LimitParam = prm.Name
+				if s != nil {
+					if n, ok := intValue(s.Default); ok && n > 0 {
+						pg.DefaultLimit = n
+					}
+					if s.Max != nil {
+						pg.MaxLimit = int(*s.Max)
+					}
+				}
+			case slices.Contains(offsetNames, name):
+				pg.OffsetParam = prm.Name
+			case slices.Contains(pageNames, name):
+				pg.PageParam = prm.Name
+				if s != nil && s.Min != nil && *s.Min == 0 {
+					pg.FirstPage = 0
+				}
+			case slices.Contains(cursorNames, name):
+				pg.CursorParam = prm.Name
+			}
+		}
+	}
+	if pg.LimitParam == "" && pg.OffsetParam == "" && pg.PageParam == "" && pg.CursorParam == "" {
+		return nil
+	}
+	if pg.MaxLimit > 0 && pg.DefaultLimit > pg.MaxLimit {
+		pg.DefaultLimit = pg.MaxLimit
+	}
+
+	if resp := bestResponseRef(op.Responses); resp != nil && resp.Value != nil {
+		for _, name := range sortedKeys(resp.Value.Headers) {
+			switch pagingName(name) {
+			case "link":
+				pg.LinkHeader = name
+			case "xtotalcount", "xtotal", "totalcount", "xtotalitems":
+				pg.TotalHeader = name
+			}
+		}
+	}
+	return pg
+}
+
+// Request returns the page the query q asks for. The offset comes from the
+// offset param, the page number or the cursor, in that order.
+func (pg *Paging) Request(q url.Values) Page {
+	page := Page{Limit: pg.DefaultLimit}
+	if n, err := strconv.Atoi(q.Get(pg.LimitParam)); pg.LimitParam != "" && err == nil && n > 0 {
+		page.Limit = n
+	}
+	if pg.MaxLimit > 0 {
+		page.Limit = min(page.Limit, pg.MaxLimit)
+	}
+
+	switch {
+	case pg.OffsetParam != "" && q.Get(pg.OffsetParam) != "":
+		if n, err := strconv.Atoi(q.Get(pg.OffsetParam)); err == nil && n > 0 {
+			page.Offset = n
+		}
+	case pg.PageParam != "" && q.Get(pg.PageParam) != "":
+		if n, err := strconv.Atoi(q.Get(pg.PageParam)); err == nil && n > pg.FirstPage {
+			if page.Limit > 0 && n-pg.FirstPage > math.MaxInt/page.Limit {
+				page.Offset = math.MaxInt
+			} else {
+				page.Offset = (n - pg.FirstPage) * page.Limit
+			}
+		}
+	case pg.CursorParam != "" && q.Get(pg.CursorParam) != "":
+		page.Offset = decodeCursor(q.Get(pg.CursorParam))
+	}
+	return page
+}
+
+// Paginate slices the list in the JSON body to the page u asks for and
+// fills the paging fields the list envelope has, such as total, next or
+// nextCursor. It returns the new body and the declared paging headers; ok
+// is false when the body holds no list.
+func (pg *Paging) Paginate(body []byte, u *url.URL) ([]byte, map[string]string, bool) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return body, nil, false
+	}
+
+	obj, _ := v.(map[string]any)
+	key := listKey(obj)
+	list, ok := v.([]any)
+	if obj != nil {
+		list, ok = obj[key].([]any)
+	}
+	if !ok {
+		return body, nil, false
+	}
+
+	page := pg.Request(u.Query())
+	total := len(list)
+	// bound huge values so that offset+limit cannot overflow below
+	page.Offset = min(page.Offset, total)
+	page.Limit = min(page.Limit, math.MaxInt-total)
+	window := list[page.Offset:min(page.Offset+page.Limit, total)]
+	links := pg.links(u, page, total)
+
+	if obj != nil {
+		obj[key] = window
+		links.fill(obj, key)
+	} else {
+		v = window
+	}
+
+	headers := map[string]string{}
+	if h := links.header(); pg.LinkHeader != "" && h != "" {
+		headers[pg.LinkHeader] = h
+	}
+	if pg.TotalHeader != "" {
+		headers[pg.TotalHeader] = strconv.Itoa(total)
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return body, nil, false
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), headers, true
+}
+
+// pageLinks holds the paging values of one page of a list.
+type pageLinks struct {
+	page       Page
+	total      int
+	more       bool
+	firstPage  int
+	number     int // page number
+	pages      int
+	self       string
+	first      string
+	prev       string
+	next       string
+	last       string
+	prevCursor string
+	nextCursor string
+}
+
+func (pg *Paging) links(u *url.URL, page Page, total int) pageLinks {
+	l := pageLinks{
+		page:      page,
+		total:     total,
+		more:      page.Offset+page.Limit < total,
+		firstPage: pg.FirstPage,
+		number:    page.Offset/page.Limit + pg.FirstPage,
+		pages:     (total + page.Limit - 1) / page.Limit,
+	}
+
+	at := func(offset int) string {
+		q := u.Query()
+		switch {
+		case pg.OffsetParam != "":
+			q.Set(pg.OffsetParam, strconv.Itoa(offset))
+		case pg.PageParam != "":
+			q.Set(pg.PageParam, strconv.Itoa(offset/page.Limit+pg.FirstPage))
+		case pg.CursorParam != "" && offset > 0:
+			q.Set(pg.CursorParam, encodeCursor(offset))
+		case pg.CursorParam != "":
+			q.Del(pg.CursorParam)
+		default:
+			return ""
+		}
+		if pg.LimitParam != "" {
+			q.Set(pg.LimitParam, strconv.Itoa(page.Limit))
+		}
+		return u.Path + "?" + q.Encode()
+	}
+
+	l.self = at(page.Offset)
+	l.first = at(0)
+	if page.Offset > 0 {
+		prev := max(page.Offset-page.Limit, 0)
+		l.prev = at(prev)
+		l.prevCursor = encodeCursor(prev)
+	}
+	if l.more {
+		l.next = at(page.Offset + page.Limit)
+		l.nextCursor = encodeCursor(page.Offset + page.Limit)
+	}
+	if pg.CursorParam == "" || pg.OffsetParam != "" || pg.PageParam != "" {
+		l.last = at(max(l.pages-1, 0) * page.Limit)
+	}
+	return l
+}
+
+// header returns the Link header of the page.
+func (l pageLinks) header() string {
+	var parts []string
+	for _, rel := range []struct{ name, url string }{
+		{"first", l.first}, {"prev", l.prev}, {"next", l.next}, {"last", l.last},
+	} {
+		if rel.url != "" {
+			parts = append(parts, "<"+rel.url+`>; rel="`+rel.name+`"`)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// fill sets the paging fields obj already has, except the list at key.
+// Links keep their shape: a URL string, an {"href": ...} object, or a page
+// number. Nested meta, pagination and links objects are filled as well.
+func (l pageLinks) fill(obj map[string]any, key string) {
+	for k, old := range obj {
+		if k == key {
+			continue
+		}
+		switch pagingName(k) {
+		case "total", "totalcount", "totalitems", "totalelements", "totalresults":
+			obj[k] = l.total
+		case "totalpages", "pagecount", "pages":
+			obj[k] = l.pages
+		case "limit", "pagesize", "perpage", "size":
+			obj[k] = l.page.Limit
+		case "offset", "skip", "start":
+			obj[k] = l.page.Offset
+		case "page", "pagenumber", "currentpage", "pageindex":
+			if m, ok := old.(map[string]any); ok {
+				l.fill(m, "")
+			} else {
+				obj[k] = l.number
+			}
+		case "hasmore", "hasnext", "hasnextpage", "more":
+			obj[k] = l.more
+		case "hasprev", "hasprevious", "haspreviouspage":
+			obj[k] = l.page.Offset > 0
+		case "cursor", "nextcursor", "nexttoken", "nextpagetoken", "continuationtoken":
+			obj[k] = orNil(l.nextCursor)
+		case "prevcursor", "previouscursor", "prevtoken", "prevpagetoken":
+			obj[k] = orNil(l.prevCursor)
+		case "next", "nexturl", "nextlink", "nexthref", "nextpage":
+			obj[k] = linkValue(old, l.next, l.number+1)
+		case "prev", "previous", "prevurl", "previousurl", "prevlink", "previouslink", "prevhref", "prevpage", "previouspage":
+			obj[k] = linkValue(old, l.prev, l.number-1)
+		case "self":
+			obj[k] = linkValue(old, l.self, l.number)
+		case "first":
+			obj[k] = linkValue(old, l.first, l.firstPage)
+		case "last":
+			obj[k] = linkValue(old, l.last, l.firstPage+max(l.pages-1, 0))
+		case "meta", "pagination", "paging", "pageinfo", "links":
+			if m, ok := old.(map[string]any); ok {
+				l.fill(m, "")
+			}
+		}
+	}
+}
+
+// linkValue returns the link to url in the shape of old: a page number
+// for numbers, an object for {"href": ...} links, otherwise a string. A
+// missing link is null.
+func linkValue(old any, url string, number int) any {
+	if url == "" {
+		return nil
+	}
+	switch t := old.(type) {
+	case json.Number, float64, int:
+		return number
+	case map[string]any:
+		if _, ok := t["href"]; ok {
+			t["href"] = url
+			return t
+		}
+	}
+	return url
+}
+
+func orNil(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// listKey returns the property of obj holding the list: an array under a
+// common name such as "items" or "data", or the only array.
+func listKey(obj map[string]any) string {
+	var arrays []string
+	for _, k := range sortedKeys(obj) {
+		if _, ok := obj[k].([]any); ok {
+			if slices.Contains(listNames, pagingName(k)) {
+				return k
+			}
+			arrays = append(arrays, k)
+		}
+	}
+	if len(arrays) == 1 {
+		return arrays[0]
+	}
+	return ""
+}
+
+// listSchema returns the schema of the list of a response schema: the
+// schema itself for arrays, or the list property of an envelope object.
+func listSchema(ref *openapi3.SchemaRef) *openapi3.SchemaRef {
+	if ref == nil || ref.Value == nil {
+		return nil
+	}
+	if schemaType(ref.Value) == openapi3.TypeArray {
+		return ref
+	}
+
+	props := openapi3.Schemas{}
+	collectProperties(ref, props, map[*openapi3.Schema]bool{})
+	var arrays []string
+	for _, name := range sortedKeys(props) {
+		if p := props[name]; p.Value != nil && schemaType(p.Value) == openapi3.TypeArray {
+			if slices.Contains(listNames, pagingName(name)) {
+				return p
+			}
+			arrays = append(arrays, name)
+		}
+	}
+	if len(arrays) == 1 {
+		return props[arrays[0]]
+	}
+	return nil
+}
+
+// collectProperties adds the properties of ref and its allOf members to
+// props.
+func collectProperties(ref *openapi3.SchemaRef, props openapi3.Schemas, seen map[*openapi3.Schema]bool) {
+	if ref == nil || ref.Value == nil || seen[ref.Value] {
+		return
+	}
+	seen[ref.Value] = true
+	for name, p := range ref.Value.Properties {
+		if p != nil {
+			props[name] = p
+		}
+	}
+	for _, m := range ref.Value.AllOf {
+		collectProperties(m, props, seen)
+	}
+}
+
+// pagingName normalizes a param or field name: "page_size", "pageSize"
+// and "X-Total-Count" become "pagesize" and "xtotalcount".
+func pagingName(name string) string {
+	return strings.Join(nameWords(name), "")
+}
+
+// encodeCursor returns the opaque cursor of a list offset.
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("offset:" + strconv.Itoa(offset)))
+}
+
+// decodeCursor returns the offset of a cursor from encodeCursor, or 0 for
+// an unknown cursor.
+func decodeCursor(cursor string) int {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0
+	}
+	n, err := strconv.Atoi(strings.TrimPrefix(string(b), "offset:"))
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
+
+func intValue(v any) (int, bool) {
+	switch t := v.(type) {
+	case float64:
+		return int(t), true
+	case int:
+		return t, true
+	case int64:
+		return int(t), true
+	case json.Number:
+		n, err := t.Int64()
+		return int(n), err == nil
+	}
+	return 0, false
+}
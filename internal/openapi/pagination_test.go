@@ -0,0 +1,232 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openapi
+
+import (
+	"encoding/json"
+	"math"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const pagingSpec = `
+openapi: 3.0.3
+info: {title: paging, version: "1"}
+paths:
+  /scans:
+    parameters:
+      - {name: offset, in: query, schema: {type: integer, minimum: 0}}
+    get:
+      parameters:
+        - {name: limit, in: query, schema: {type: integer, default: 2, maximum: 5}}
+      responses:
+        "200":
+          description: ok
+          headers:
+            Link: {schema: {type: string}}
+            X-Total-Count: {schema: {type: integer}}
+          content:
+            application/json:
+              schema:
+                type: array
+                items: {$ref: "#/components/schemas/Scan"}
+  /results:
+    get:
+      parameters:
+        - {name: page_size, in: query, schema: {type: integer}}
+        - {name: page, in: query, schema: {type: integer, minimum: 0}}
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                allOf:
+                  - $ref: "#/components/schemas/Envelope"
+                  - type: object
+                    properties:
+                      data: {type: array, items: {$ref: "#/components/schemas/Scan"}}
+  /reports:
+    get:
+      responses:
+        "200":
+          description: ok
+components:
+  schemas:
+    Scan:
+      type: object
+      properties:
+        id: {type: string, format: uuid}
+    Envelope:
+      type: object
+      properties:
+        total: {type: integer}
+        page: {type: integer}
+        tags: {type: array, items: {type: string}}
+`
+
+func TestFindPaging(t *testing.T) {
+	p := newTestProvider(t, pagingSpec)
+
+	want := &Paging{
+		LimitParam: "limit", OffsetParam: "offset",
+		DefaultLimit: 2, MaxLimit: 5, FirstPage: 1,
+		LinkHeader: "Link", TotalHeader: "X-Total-Count",
+	}
+	if got := p.FindPaging("/scans", "GET"); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+
+	want = &Paging{LimitParam: "page_size", PageParam: "page", DefaultLimit: 10}
+	if got := p.FindPaging("/results", "GET"); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+
+	if got := p.FindPaging("/reports", "GET"); got != nil {
+		t.Fatalf("expected no paging, got %+v", got)
+	}
+}
+
+func TestPagingRequest(t *testing.T) {
+	pg := &Paging{LimitParam: "limit", OffsetParam: "offset", PageParam: "page", CursorParam: "cursor", DefaultLimit: 10, MaxLimit: 50, FirstPage: 1}
+
+	cases := map[string]Page{
+		"":                           {Offset: 0, Limit: 10},
+		"limit=5&offset=12":          {Offset: 12, Limit: 5},
+		"limit=500":                  {Offset: 0, Limit: 50},
+		"limit=-1&offset=-3":         {Offset: 0, Limit: 10},
+		"limit=5&page=3":             {Offset: 10, Limit: 5},
+		"cursor=" + encodeCursor(30): {Offset: 30, Limit: 10},
+		"cursor=bogus":               {Offset: 0, Limit: 10},
+	}
+	for query, want := range cases {
+		q, _ := url.ParseQuery(query)
+		if got := pg.Request(q); got != want {
+			t.Fatalf("%q: expected %+v, got %+v", query, want, got)
+		}
+	}
+}
+
+func TestPaginate_BareArrayWithHeaders(t *testing.T) {
+	pg := &Paging{LimitParam: "limit", OffsetParam: "offset", DefaultLimit: 2, FirstPage: 1, LinkHeader: "Link", TotalHeader: "X-Total-Count"}
+	u, _ := url.Parse("/api/scans?offset=2&limit=2&q=x")
+
+	body, headers, ok := pg.Paginate([]byte(`[1,2,3,4,5]`), u)
+	if !ok || string(body) != `[3,4]` {
+		t.Fatalf("unexpected page %s", body)
+	}
+	want := map[string]string{
+		"Link": `</api/scans?limit=2&offset=0&q=x>; rel="first", ` +
+			`</api/scans?limit=2&offset=0&q=x>; rel="prev", ` +
+			`</api/scans?limit=2&offset=4&q=x>; rel="next", ` +
+			`</api/scans?limit=2&offset=4&q=x>; rel="last"`,
+		"X-Total-Count": "5",
+	}
+	if !reflect.DeepEqual(headers, want) {
+		t.Fatalf("expected headers %v, got %v", want, headers)
+	}
+
+	if _, _, ok := pg.Paginate([]byte(`{"id":1}`), u); ok {
+		t.Fatalf("expected no list in a plain object")
+	}
+}
+
+func TestPaginate_EnvelopeFields(t *testing.T) {
+	pg := &Paging{PageParam: "page", CursorParam: "cursor", DefaultLimit: 2, FirstPage: 1}
+	u, _ := url.Parse("/results?page=2")
+
+	body, _, ok := pg.Paginate([]byte(`{
+		"data": [{"id":1},{"id":2},{"id":3},{"id":4},{"id":5}],
+		"tags": ["a"],
+		"total": 0, "page": 0, "hasMore": false, "nextCursor": "x", "prev": 1,
+		"links": {"next": {"href": ""}, "self": "", "last": ""},
+		"big": 12345678901234567890
+	}`), u)
+	if !ok {
+		t.Fatalf("expected a list")
+	}
+	var got map[string]any
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("decode %s: %v", body, err)
+	}
+	want := map[string]any{
+		"data":       []any{map[string]any{"id": float64(3)}, map[string]any{"id": float64(4)}},
+		"tags":       []any{"a"},
+		"total":      float64(5),
+		"page":       float64(2),
+		"hasMore":    true,
+		"nextCursor": encodeCursor(4),
+		"prev":       float64(1),
+		"links": map[string]any{
+			"next": map[string]any{"href": "/results?page=3"},
+			"self": "/results?page=2",
+			"last": "/results?page=3",
+		},
+		"big": 1.2345678901234567e19,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected envelope:\n%s", body)
+	}
+	if !json.Valid(body) || !strings.Contains(string(body), "12345678901234567890") {
+		t.Fatalf("expected numbers to be kept as written: %s", body)
+	}
+
+	u, _ = url.Parse("/results?page=3")
+	body, _, _ = pg.Paginate([]byte(`{"data":[1,2,3,4,5],"next":"x","hasMore":true}`), u)
+	if string(body) != `{"data":[5],"hasMore":false,"next":null}` {
+		t.Fatalf("unexpected last page %s", body)
+	}
+}
+
+func TestPaginate_HugeValues(t *testing.T) {
+	huge := strconv.Itoa(math.MaxInt)
+	pg := &Paging{LimitParam: "limit", OffsetParam: "offset", PageParam: "page", CursorParam: "cursor", DefaultLimit: 10, FirstPage: 1}
+
+	cases := map[string]string{
+		"page=" + huge:                           `[]`,
+		"limit=" + huge + "&offset=1":            `[2,3]`,
+		"offset=" + huge:                         `[]`,
+		"limit=" + huge + "&offset=" + huge:      `[]`,
+		"cursor=" + encodeCursor(math.MaxInt):    `[]`,
+		"limit=" + huge + "&page=" + huge:        `[]`,
+		"limit=" + huge + "&page=2":              `[]`,
+		"limit=" + huge + "&page=1&offset=0":     `[1,2,3]`,
+		"page=" + strconv.Itoa(math.MaxInt/10+2): `[]`,
+	}
+	for query, want := range cases {
+		u, _ := url.Parse("/scans?" + query)
+		body, _, ok := pg.Paginate([]byte(`[1,2,3]`), u)
+		if !ok || string(body) != want {
+			t.Fatalf("%q: expected %s, got %s", query, want, body)
+		}
+	}
+}
+
+func TestGenerate_ListLength(t *testing.T) {
+	p := newTestProvider(t, pagingSpec)
+
+	for path, count := range map[string]func(any) int{
+		"/scans":   func(v any) int { return len(v.([]any)) },
+		"/results": func(v any) int { return len(v.(map[string]any)["data"].([]any)) },
+	} {
+		ex, ok := p.TryGetExample(path, "GET", ExampleOptions{ListLength: 7})
+		if !ok {
+			t.Fatalf("%s: expected example", path)
+		}
+		var v any
+		if err := json.Unmarshal(ex.Body, &v); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if n := count(v); n != 7 {
+			t.Fatalf("%s: expected 7 list items, got %d in %s", path, n, ex.Body)
+		}
+		if m, ok := v.(map[string]any); ok && len(m["tags"].([]any)) != 1 {
+			t.Fatalf("%s: expected other arrays to keep their length: %s", path, ex.Body)
+		}
+	}
+}
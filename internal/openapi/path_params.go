@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openapi
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// uuidPattern accepts any UUID version; kin-openapi does not check the
+// "uuid" format by default.
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// pathParamSchemas returns the schemas of the path parameters declared on
+// the path item and the operation; operation parameters win.
+func pathParamSchemas(item *openapi3.PathItem, op *openapi3.Operation) map[string]*openapi3.Schema {
+	out := map[string]*openapi3.Schema{}
+	for _, params := range []openapi3.Parameters{item.Parameters, op.Parameters} {
+		for _, p := range params {
+			if p == nil || p.Value == nil || p.Value.In != openapi3.ParameterInPath {
+				continue
+			}
+			if p.Value.Schema != nil && p.Value.Schema.Value != nil {
+				out[p.Value.Name] = p.Value.Schema.Value
+			}
+		}
+	}
+	return out
+}
+
+// constrainedParams counts the path params that accept less than any
+// string.
+func constrainedParams(r *Route) int {
+	n := 0
+	for _, sch := range r.params {
+		if !isPlainString(sch) {
+			n++
+		}
+	}
+	return n
+}
+
+func isPlainString(sch *openapi3.Schema) bool {
+	if sch.Type != nil && !sch.Type.Is(openapi3.TypeString) {
+		return false
+	}
+	return sch.Format == "" && sch.Pattern == "" && len(sch.Enum) == 0 &&
+		sch.MinLength == 0 && sch.MaxLength == nil &&
+		len(sch.AllOf) == 0 && len(sch.AnyOf) == 0 && len(sch.OneOf) == 0
+}
+
+// checkParams checks the decoded path segments against the param schemas
+// of r.
+func (r *Route) checkParams(segs []string) *ParamError {
+	for i, seg := range r.segments {
+		name, ok := pathParamName(seg)
+		if !ok {
+			continue
+		}
+		sch := r.params[name]
+		if sch == nil {
+			continue
+		}
+		if reason := checkParamValue(sch, segs[i]); reason != "" {
+			return &ParamError{Template: r.Swagger, Param: name, Value: segs[i], Reason: reason}
+		}
+	}
+	return nil
+}
+
+// checkParamValue returns why raw does not fit sch, or "" if it does. The
+// raw value is converted to the schema type before schema validation.
+func checkParamValue(sch *openapi3.Schema, raw string) string {
+	var value any = raw
+	switch {
+	case sch.Type.Is(openapi3.TypeInteger):
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return "must be an integer"
+		}
+		value = float64(n)
+	case sch.Type.Is(openapi3.TypeNumber):
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return "must be a number"
+		}
+		value = f
+	case sch.Type.Is(openapi3.TypeBoolean):
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return "must be a boolean"
+		}
+		value = b
+	}
+
+	if s, ok := value.(string); ok && sch.Format == "uuid" && !uuidPattern.MatchString(s) {
+		return "must be a uuid"
+	}
+
+	if err := sch.VisitJSON(value); err != nil {
+		var serr *openapi3.SchemaError
+		if errors.As(err, &serr) && serr.Reason != "" {
+			return serr.Reason
+		}
+		return compactMessage(err.Error())
+	}
+	return ""
+}
@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openapi
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func typedParamsSpec(t *testing.T) *Spec {
+	t.Helper()
+
+	param := func(name, schema string) string {
+		return `{"name":"` + name + `","in":"path","required":true,"schema":` + schema + `}`
+	}
+	op := func(params ...string) string {
+		return `{"get":{"parameters":[` + strings.Join(params, ",") + `],"responses":{"200":{"description":"ok"}}}}`
+	}
+
+	raw := `{
+	  "openapi":"3.0.3",
+	  "info":{"title":"t","version":"1"},
+	  "paths":{
+	    "/items/{id}":` + op(param("id", `{"type":"integer"}`)) + `,
+	    "/items/{name}":` + op(param("name", `{"type":"string"}`)) + `,
+	    "/users/{id}":` + op(param("id", `{"type":"integer","minimum":1}`)) + `,
+	    "/tasks/{uuid}":` + op(param("uuid", `{"type":"string","format":"uuid"}`)) + `,
+	    "/colors/{c}":` + op(param("c", `{"type":"string","enum":["red","blue"]}`)) + `,
+	    "/codes/{code}":` + op(param("code", `{"type":"string","pattern":"^[A-Z]{3}$"}`)) + `,
+	    "/scans/{id}":` + op(param("id", `{"type":"integer"}`)) + `,
+	    "/scans/preferences":` + op() + `
+	  }
+	}`
+
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(raw))
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	return &Spec{Doc3: doc}
+}
+
+func TestFindRoute_TypedParams_Disambiguate(t *testing.T) {
+	p := NewRouterProvider(typedParamsSpec(t), RouterConfig{})
+
+	m, err := p.FindRoute("GET", "/items/42")
+	if err != nil || m.Route.Swagger != "/items/{id}" || m.Params["id"] != "42" {
+		t.Fatalf("expected /items/{id}, got %#v err=%v", m, err)
+	}
+
+	m, err = p.FindRoute("GET", "/items/abc")
+	if err != nil || m.Route.Swagger != "/items/{name}" || m.Params["name"] != "abc" {
+		t.Fatalf("expected /items/{name}, got %#v err=%v", m, err)
+	}
+
+	m, err = p.FindRoute("GET", "/scans/preferences")
+	if err != nil || m.Route.Swagger != "/scans/preferences" {
+		t.Fatalf("expected literal route, got %#v err=%v", m, err)
+	}
+}
+
+func TestFindRoute_TypedParams_Mismatch(t *testing.T) {
+	p := NewRouterProvider(typedParamsSpec(t), RouterConfig{})
+
+	cases := []struct {
+		path   string
+		tpl    string
+		reason string
+	}{
+		{"/users/abc", "/users/{id}", "must be an integer"},
+		{"/users/0", "/users/{id}", "at least 1"},
+		{"/tasks/not-a-uuid", "/tasks/{uuid}", "must be a uuid"},
+		{"/colors/green", "/colors/{c}", "allowed values"},
+		{"/codes/ab1", "/codes/{code}", "regular expression"},
+		{"/scans/x", "/scans/{id}", "must be an integer"},
+	}
+
+	for _, tc := range cases {
+		m, err := p.FindRoute("GET", tc.path)
+		if m != nil {
+			t.Fatalf("%s: expected no match, got %#v", tc.path, m)
+		}
+
+		var perr *ParamError
+		if !errors.As(err, &perr) {
+			t.Fatalf("%s: expected *ParamError, got %v", tc.path, err)
+		}
+		if !errors.Is(err, ErrNoRoute) {
+			t.Fatalf("%s: expected ParamError to unwrap to ErrNoRoute", tc.path)
+		}
+		if perr.Template != tc.tpl || perr.Method != "GET" || perr.Path != tc.path {
+			t.Fatalf("%s: unexpected error fields: %#v", tc.path, perr)
+		}
+		if !strings.Contains(perr.Reason, tc.reason) {
+			t.Fatalf("%s: expected reason to contain %q, got %q", tc.path, tc.reason, perr.Reason)
+		}
+	}
+}
+
+func TestFindRoute_TypedParams_Valid(t *testing.T) {
+	p := NewRouterProvider(typedParamsSpec(t), RouterConfig{})
+
+	for _, path := range []string{
+		"/users/7",
+		"/tasks/3f2504e0-4f89-11d3-9a0c-0305e82c3301",
+		"/colors/blue",
+		"/codes/ABC",
+	} {
+		if m, err := p.FindRoute("GET", path); err != nil || m == nil {
+			t.Fatalf("%s: expected match, got err=%v", path, err)
+		}
+	}
+}
+
+func TestFindRoute_NoRoute(t *testing.T) {
+	p := NewRouterProvider(typedParamsSpec(t), RouterConfig{})
+
+	_, err := p.FindRoute("GET", "/nope")
+	if !errors.Is(err, ErrNoRoute) {
+		t.Fatalf("expected ErrNoRoute, got %v", err)
+	}
+	var perr *ParamError
+	if errors.As(err, &perr) {
+		t.Fatalf("expected plain ErrNoRoute, got %v", perr)
+	}
+}
+
+func TestCheckParamValue_Types(t *testing.T) {
+	num := &openapi3.Schema{Type: &openapi3.Types{openapi3.TypeNumber}}
+	boolean := &openapi3.Schema{Type: &openapi3.Types{openapi3.TypeBoolean}}
+	untyped := &openapi3.Schema{}
+
+	if r := checkParamValue(num, "1.5"); r != "" {
+		t.Fatalf("expected number to pass, got %q", r)
+	}
+	if r := checkParamValue(num, "x"); r != "must be a number" {
+		t.Fatalf("unexpected reason %q", r)
+	}
+	if r := checkParamValue(boolean, "true"); r != "" {
+		t.Fatalf("expected boolean to pass, got %q", r)
+	}
+	if r := checkParamValue(boolean, "yes"); r != "must be a boolean" {
+		t.Fatalf("unexpected reason %q", r)
+	}
+	if r := checkParamValue(untyped, "anything"); r != "" {
+		t.Fatalf("expected untyped schema to accept anything, got %q", r)
+	}
+}
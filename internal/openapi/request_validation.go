@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+// Violation is one way in which a request or response breaks the spec.
+type Violation struct {
+	// In is "path", "query", "header", "cookie", "body", "status" or
+	// "request".
+	In string `json:"in"`
+	// Name is the parameter or header name, or a JSON pointer into the body.
+	Name    string `json:"name,omitempty"`
+	Message string `json:"message"`
+}
+
+// ValidateRequest checks parameters, content type and body of r against
+// the matched operation and returns every violation found. Security
+// requirements are not checked here.
+func (v *Validator) ValidateRequest(r *http.Request, match *RouteMatch) []Violation {
+	input := v.requestInput(r, match)
+	if input == nil {
+		return nil
+	}
+
+	err := openapi3filter.ValidateRequest(context.Background(), input)
+	if err == nil {
+		return nil
+	}
+	return requestViolations(err)
+}
+
+// requestInput builds the openapi3filter input for the matched operation,
+// or returns nil when the spec has no such operation.
+func (v *Validator) requestInput(r *http.Request, match *RouteMatch) *openapi3filter.RequestValidationInput {
+	spec := v.spec.GetSpec()
+	if spec == nil || spec.Doc3 == nil || spec.Doc3.Paths == nil {
+		return nil
+	}
+	item := spec.Doc3.Paths.Value(match.Route.Swagger)
+	op := v.spec.FindOperation(match.Route.Swagger, match.Route.Method)
+	if item == nil || op == nil {
+		return nil
+	}
+
+	return &openapi3filter.RequestValidationInput{
+		Request:    r,
+		PathParams: match.Params,
+		Route: &routers.Route{
+			Spec:      spec.Doc3,
+			Path:      match.Route.Swagger,
+			PathItem:  item,
+			Method:    match.Route.Method,
+			Operation: op,
+		},
+		Options: &openapi3filter.Options{
+			MultiError:          true,
+			SkipSettingDefaults: true,
+			AuthenticationFunc:  openapi3filter.NoopAuthenticationFunc,
+		},
+	}
+}
+
+func requestViolations(err error) []Violation {
+	// a type assertion, not errors.As: RequestError unwraps to its own
+	// MultiError of schema errors
+	if me, ok := err.(openapi3.MultiError); ok {
+		var out []Violation
+		for _, e := range me {
+			out = append(out, requestViolations(e)...)
+		}
+		return out
+	}
+
+	var rerr *openapi3filter.RequestError
+	if !errors.As(err, &rerr) {
+		return []Violation{{In: "request", Message: compactMessage(err.Error())}}
+	}
+
+	switch {
+	case rerr.Parameter != nil:
+		return []Violation{{
+			In:      rerr.Parameter.In,
+			Name:    rerr.Parameter.Name,
+			Message: requestErrorReason(rerr),
+		}}
+	case rerr.RequestBody != nil:
+		if out := bodySchemaViolations(rerr.Err); len(out) > 0 {
+			return out
+		}
+		return []Violation{{In: "body", Message: requestErrorReason(rerr)}}
+	default:
+		return []Violation{{In: "request", Message: requestErrorReason(rerr)}}
+	}
+}
+
+// bodySchemaViolations returns one violation per schema error, located by
+// a JSON pointer into the body.
+func bodySchemaViolations(err error) []Violation {
+	var errs []error
+	var me openapi3.MultiError
+	if errors.As(err, &me) {
+		errs = me
+	} else if err != nil {
+		errs = []error{err}
+	}
+
+	var out []Violation
+	for _, e := range errs {
+		var serr *openapi3.SchemaError
+		if !errors.As(e, &serr) {
+			return nil
+		}
+		out = append(out, Violation{
+			In:      "body",
+			Name:    "/" + strings.Join(serr.JSONPointer(), "/"),
+			Message: serr.Reason,
+		})
+	}
+	return out
+}
+
+func requestErrorReason(rerr *openapi3filter.RequestError) string {
+	return errorReason(rerr.Reason, rerr.Err)
+}
+
+// errorReason joins a filter error reason with its cause. Schema errors
+// are reduced to their own reason.
+func errorReason(reason string, err error) string {
+	var serr *openapi3.SchemaError
+	switch {
+	case errors.As(err, &serr):
+		return serr.Reason
+	case err == nil:
+		return reason
+	}
+
+	msg := compactMessage(err.Error())
+	if reason == "" || reason == msg {
+		return msg
+	}
+	return reason + ": " + msg
+}
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openapi
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const strictSpec = `{
+  "openapi":"3.0.3",
+  "info":{"title":"t","version":"1"},
+  "paths":{
+    "/items/{id}":{
+      "parameters":[{"name":"id","in":"path","required":true,"schema":{"type":"integer"}}],
+      "post":{
+        "parameters":[
+          {"name":"limit","in":"query","schema":{"type":"integer","maximum":10}},
+          {"name":"X-Request-Id","in":"header","required":true,"schema":{"type":"string"}},
+          {"name":"session","in":"cookie","required":true,"schema":{"type":"string"}}
+        ],
+        "requestBody":{
+          "required":true,
+          "content":{"application/json":{"schema":{
+            "type":"object",
+            "required":["name"],
+            "properties":{
+              "name":{"type":"string"},
+              "count":{"type":"integer","minimum":0}
+            }
+          }}}
+        },
+        "responses":{"200":{"description":"ok"}}
+      }
+    }
+  }
+}`
+
+func TestValidateRequest_Valid(t *testing.T) {
+	v, router := newTestValidator(t, strictSpec)
+
+	r, m := routedRequest(t, router, http.MethodPost, "/items/1?limit=5", `{"name":"a","count":1}`, map[string]string{"Content-Type": "application/json"})
+	r.Header.Set("X-Request-Id", "abc")
+	r.AddCookie(&http.Cookie{Name: "session", Value: "s"})
+
+	if got := v.ValidateRequest(r, m); len(got) != 0 {
+		t.Fatalf("expected no violations, got %#v", got)
+	}
+
+	// the body stays readable for later handlers
+	if empty, err := v.IsEmptyBody(r); err != nil || empty {
+		t.Fatalf("expected body to be preserved, empty=%v err=%v", empty, err)
+	}
+}
+
+func TestValidateRequest_ReportsEveryViolation(t *testing.T) {
+	v, router := newTestValidator(t, strictSpec)
+
+	r, m := routedRequest(t, router, http.MethodPost, "/items/1?limit=abc", `{"count":-1}`, map[string]string{"Content-Type": "application/json"})
+
+	got := v.ValidateRequest(r, m)
+
+	want := map[string]string{
+		"query:limit":         "",
+		"header:X-Request-Id": "",
+		"cookie:session":      "",
+		"body:/name":          "",
+		"body:/count":         "",
+	}
+	for _, vi := range got {
+		key := vi.In + ":" + vi.Name
+		if _, ok := want[key]; !ok {
+			t.Fatalf("unexpected violation %#v", vi)
+		}
+		if vi.Message == "" {
+			t.Fatalf("expected a message for %s", key)
+		}
+		delete(want, key)
+	}
+	if len(want) > 0 {
+		t.Fatalf("missing violations %v, got %#v", want, got)
+	}
+}
+
+func TestValidateRequest_ContentTypeAndMissingBody(t *testing.T) {
+	v, router := newTestValidator(t, strictSpec)
+
+	r, m := routedRequest(t, router, http.MethodPost, "/items/1", `hello`, map[string]string{"Content-Type": "text/plain"})
+	r.Header.Set("X-Request-Id", "abc")
+	r.AddCookie(&http.Cookie{Name: "session", Value: "s"})
+
+	got := v.ValidateRequest(r, m)
+	if len(got) != 1 || got[0].In != "body" || !strings.Contains(got[0].Message, "Content-Type") {
+		t.Fatalf("expected content type violation, got %#v", got)
+	}
+
+	r, m = routedRequest(t, router, http.MethodPost, "/items/1", "", nil)
+	r.Header.Set("X-Request-Id", "abc")
+	r.AddCookie(&http.Cookie{Name: "session", Value: "s"})
+
+	got = v.ValidateRequest(r, m)
+	if len(got) != 1 || got[0].In != "body" || !strings.Contains(got[0].Message, "required") {
+		t.Fatalf("expected missing body violation, got %#v", got)
+	}
+}
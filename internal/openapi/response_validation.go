@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+)
+
+// ValidateResponse checks status, headers and body of a response served
+// for r against the declared responses of the matched operation.
+func (v *Validator) ValidateResponse(r *http.Request, match *RouteMatch, status int, header http.Header, body []byte) []Violation {
+	input := v.requestInput(r, match)
+	if input == nil {
+		return nil
+	}
+
+	rin := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 status,
+		Header:                 header,
+		Options: &openapi3filter.Options{
+			MultiError:            true,
+			IncludeResponseStatus: true,
+		},
+	}
+	rin.SetBodyBytes(body)
+
+	err := openapi3filter.ValidateResponse(context.Background(), rin)
+	if err == nil {
+		return nil
+	}
+	return responseViolations(err, status)
+}
+
+func responseViolations(err error, status int) []Violation {
+	if me, ok := err.(openapi3.MultiError); ok {
+		var out []Violation
+		for _, e := range me {
+			out = append(out, responseViolations(e, status)...)
+		}
+		return out
+	}
+
+	var rerr *openapi3filter.ResponseError
+	if !errors.As(err, &rerr) {
+		return []Violation{{In: "response", Message: compactMessage(err.Error())}}
+	}
+
+	switch {
+	case rerr.Reason == "status is not supported":
+		return []Violation{{
+			In:      "status",
+			Message: fmt.Sprintf("status %d is not declared for the operation", status),
+		}}
+	case strings.Contains(rerr.Reason, "Content-Type"):
+		return []Violation{{In: "header", Name: "Content-Type", Message: errorReason(rerr.Reason, rerr.Err)}}
+	case strings.Contains(rerr.Reason, "header"):
+		return []Violation{{In: "header", Name: quotedName(rerr.Reason), Message: errorReason(rerr.Reason, rerr.Err)}}
+	default:
+		if out := bodySchemaViolations(rerr.Err); len(out) > 0 {
+			return out
+		}
+		return []Violation{{In: "body", Message: errorReason(rerr.Reason, rerr.Err)}}
+	}
+}
+
+// quotedName returns the first quoted string of a filter error reason,
+// e.g. the header name of `response header "X-Rate" missing`.
+func quotedName(reason string) string {
+	i := strings.IndexByte(reason, '"')
+	if i < 0 {
+		return ""
+	}
+	q, err := strconv.QuotedPrefix(reason[i:])
+	if err != nil {
+		return ""
+	}
+	name, _ := strconv.Unquote(q)
+	return name
+}
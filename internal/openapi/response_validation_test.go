@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openapi
+
+import (
+	"net/http"
+	"testing"
+)
+
+const responseSpec = `{
+  "openapi":"3.0.3",
+  "info":{"title":"t","version":"1"},
+  "paths":{
+    "/items/{id}":{
+      "get":{
+        "parameters":[{"name":"id","in":"path","required":true,"schema":{"type":"string"}}],
+        "responses":{
+          "200":{
+            "description":"ok",
+            "headers":{"X-Rate":{"required":true,"schema":{"type":"integer"}}},
+            "content":{"application/json":{"schema":{
+              "type":"object",
+              "required":["id"],
+              "properties":{"id":{"type":"integer"}}
+            }}}
+          },
+          "404":{"description":"missing"}
+        }
+      }
+    }
+  }
+}`
+
+func jsonHeader(extra ...string) http.Header {
+	h := http.Header{"Content-Type": {"application/json"}}
+	for i := 0; i+1 < len(extra); i += 2 {
+		h.Set(extra[i], extra[i+1])
+	}
+	return h
+}
+
+func TestValidateResponse_Valid(t *testing.T) {
+	v, router := newTestValidator(t, responseSpec)
+	r, m := routedRequest(t, router, http.MethodGet, "/items/1", "", nil)
+
+	got := v.ValidateResponse(r, m, 200, jsonHeader("X-Rate", "5"), []byte(`{"id":1}`))
+	if len(got) != 0 {
+		t.Fatalf("expected no violations, got %#v", got)
+	}
+
+	if got := v.ValidateResponse(r, m, 404, http.Header{}, nil); len(got) != 0 {
+		t.Fatalf("expected declared 404 without content to pass, got %#v", got)
+	}
+}
+
+func TestValidateResponse_Body(t *testing.T) {
+	v, router := newTestValidator(t, responseSpec)
+	r, m := routedRequest(t, router, http.MethodGet, "/items/1", "", nil)
+
+	got := v.ValidateResponse(r, m, 200, jsonHeader("X-Rate", "5"), []byte(`{"id":"x"}`))
+	if len(got) != 1 || got[0].In != "body" || got[0].Name != "/id" {
+		t.Fatalf("expected body violation at /id, got %#v", got)
+	}
+}
+
+func TestValidateResponse_Header(t *testing.T) {
+	v, router := newTestValidator(t, responseSpec)
+	r, m := routedRequest(t, router, http.MethodGet, "/items/1", "", nil)
+
+	got := v.ValidateResponse(r, m, 200, jsonHeader(), []byte(`{"id":1}`))
+	if len(got) != 1 || got[0].In != "header" || got[0].Name != "X-Rate" {
+		t.Fatalf("expected missing header violation, got %#v", got)
+	}
+
+	got = v.ValidateResponse(r, m, 200, http.Header{"Content-Type": {"text/plain"}, "X-Rate": {"1"}}, []byte(`x`))
+	if len(got) != 1 || got[0].In != "header" || got[0].Name != "Content-Type" {
+		t.Fatalf("expected content type violation, got %#v", got)
+	}
+}
+
+func TestValidateResponse_UndeclaredStatus(t *testing.T) {
+	v, router := newTestValidator(t, responseSpec)
+	r, m := routedRequest(t, router, http.MethodGet, "/items/1", "", nil)
+
+	got := v.ValidateResponse(r, m, 418, jsonHeader(), []byte(`{}`))
+	if len(got) != 1 || got[0].In != "status" || got[0].Message != "status 418 is not declared for the operation" {
+		t.Fatalf("expected status violation, got %#v", got)
+	}
+}
+
+func TestQuotedName(t *testing.T) {
+	if got := quotedName(`response header "X-Rate" missing`); got != "X-Rate" {
+		t.Fatalf("unexpected name %q", got)
+	}
+	if got := quotedName("no quotes"); got != "" {
+		t.Fatalf("expected empty name, got %q", got)
+	}
+}
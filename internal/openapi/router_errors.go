@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNoRoute is returned by FindRoute when no route matches the request.
+var ErrNoRoute = errors.New("no route")
+
+// ParamError reports a path that matches a route template, but whose path
+// parameter does not fit the declared schema. It unwraps to ErrNoRoute.
+type ParamError struct {
+	Method   string
+	Path     string
+	Template string
+	Param    string
+	Value    string
+	Reason   string
+}
+
+func (e *ParamError) Error() string {
+	return fmt.Sprintf("path parameter %q of %s %s: value %q %s",
+		e.Param, e.Method, e.Template, e.Value, e.Reason)
+}
+
+func (e *ParamError) Unwrap() error {
+	return ErrNoRoute
+}
+
+// MethodNotAllowedError reports a path that has routes, but none for the
+// requested method. Allowed lists the spec methods of the path.
+type MethodNotAllowedError struct {
+	Method  string
+	Path    string
+	Allowed []string
+}
+
+func (e *MethodNotAllowedError) Error() string {
+	return fmt.Sprintf("method %s not allowed for %s (allowed: %s)",
+		e.Method, e.Path, strings.Join(e.Allowed, ", "))
+}
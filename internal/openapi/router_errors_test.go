@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openapi
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFindRoute_MethodNotAllowed(t *testing.T) {
+	p := newRouterProvider([]Route{
+		{Method: "GET", Swagger: "/scans/{id}"},
+		{Method: "DELETE", Swagger: "/scans/{id}"},
+		{Method: "POST", Swagger: "/scans/preferences"},
+	})
+
+	_, err := p.FindRoute("PUT", "/scans/1")
+	var mna *MethodNotAllowedError
+	if !errors.As(err, &mna) {
+		t.Fatalf("expected *MethodNotAllowedError, got %v", err)
+	}
+	if !reflect.DeepEqual(mna.Allowed, []string{"DELETE", "GET"}) {
+		t.Fatalf("unexpected allowed methods: %v", mna.Allowed)
+	}
+	if mna.Method != "PUT" || mna.Path != "/scans/1" {
+		t.Fatalf("unexpected error fields: %#v", mna)
+	}
+	if !strings.Contains(mna.Error(), "allowed: DELETE, GET") {
+		t.Fatalf("unexpected message: %q", mna.Error())
+	}
+	if errors.Is(err, ErrNoRoute) {
+		t.Fatalf("method not allowed must not be reported as no route")
+	}
+
+	// the literal and the param branch both contribute
+	_, err = p.FindRoute("PUT", "/scans/preferences")
+	if !errors.As(err, &mna) || !reflect.DeepEqual(mna.Allowed, []string{"DELETE", "GET", "POST"}) {
+		t.Fatalf("expected DELETE, GET, POST, got %v", err)
+	}
+
+	_, err = p.FindRoute("PUT", "/unknown")
+	if !errors.Is(err, ErrNoRoute) {
+		t.Fatalf("expected ErrNoRoute for an unknown path, got %v", err)
+	}
+}
+
+func TestFindRoute_MethodNotAllowed_RespectsParamSchemas(t *testing.T) {
+	p := NewRouterProvider(typedParamsSpec(t), RouterConfig{})
+
+	// /users/{id} only accepts integers, so "abc" is not a known path
+	_, err := p.FindRoute("DELETE", "/users/abc")
+	var mna *MethodNotAllowedError
+	if errors.As(err, &mna) {
+		t.Fatalf("expected no method list for a mismatching param, got %v", mna)
+	}
+	if !errors.Is(err, ErrNoRoute) {
+		t.Fatalf("expected ErrNoRoute, got %v", err)
+	}
+
+	_, err = p.FindRoute("DELETE", "/users/1")
+	if !errors.As(err, &mna) || !reflect.DeepEqual(mna.Allowed, []string{"GET"}) {
+		t.Fatalf("expected GET to be allowed, got %v", err)
+	}
+}
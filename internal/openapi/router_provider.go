@@ -6,15 +6,31 @@ package openapi
 
 import (
 	"fmt"
-	"regexp"
+	"net/url"
+	"sort"
 	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/ozgen/openapi-emulator/config"
 )
 
+// RouterProvider matches request paths with a segment trie built from the
+// spec paths. At every segment a literal child is tried before the {param}
+// child, so "/scans/preferences" wins over "/scans/{id}".
 type RouterProvider struct {
-	routes []Route
+	routes    []Route
+	root      *routeNode
+	basePaths []string
+	mode      config.BasePathMode
+}
+
+type routeNode struct {
+	literals map[string]*routeNode
+	param    *routeNode
+	routes   map[string][]*Route // by method, most constrained params first
 }
 
-func NewRouterProvider(spec *Spec) IRouterProvider {
+func NewRouterProvider(spec *Spec, cfg RouterConfig) IRouterProvider {
 	if spec == nil || spec.Doc3 == nil || spec.Doc3.Paths == nil {
 		return nil
 	}
@@ -25,84 +41,342 @@ func NewRouterProvider(spec *Spec) IRouterProvider {
 			continue
 		}
 
-		for method := range item.Operations() {
+		for method, op := range item.Operations() {
 			m := strings.ToUpper(method)
 			out = append(out, Route{
 				Method:     m,
 				Swagger:    swaggerPath,
-				Regex:      swaggerPathToRegex(swaggerPath),
 				SampleFile: swaggerPathToSampleName(m, swaggerPath),
+				params:     pathParamSchemas(item, op),
 			})
 		}
 	}
-	return &RouterProvider{routes: out}
-}
 
-func (p *RouterProvider) FindRoute(method, path string) *Route {
-	method = strings.ToUpper(method)
+	p := newRouterProvider(out)
+	p.basePaths = specBasePaths(spec)
+	if cfg.BasePathMode != "" {
+		p.mode = cfg.BasePathMode
+	}
+	return p
+}
 
-	var best *Route
-	bestScore := -1
+func newRouterProvider(routes []Route) *RouterProvider {
+	sort.SliceStable(routes, func(i, j int) bool {
+		if routes[i].Swagger != routes[j].Swagger {
+			return routes[i].Swagger < routes[j].Swagger
+		}
+		return routes[i].Method < routes[j].Method
+	})
 
+	p := &RouterProvider{
+		routes: routes,
+		root:   &routeNode{},
+		mode:   config.BasePathAuto,
+	}
 	for i := range p.routes {
-		r := &p.routes[i]
-		if r.Method != method {
+		p.insert(&p.routes[i])
+	}
+	return p
+}
+
+func (p *RouterProvider) insert(r *Route) {
+	r.segments = splitPath(r.Swagger)
+
+	n := p.root
+	for _, seg := range r.segments {
+		if _, ok := pathParamName(seg); ok {
+			if n.param == nil {
+				n.param = &routeNode{}
+			}
+			n = n.param
 			continue
 		}
-		if !r.Regex.MatchString(path) {
+
+		if n.literals == nil {
+			n.literals = map[string]*routeNode{}
+		}
+		child, ok := n.literals[seg]
+		if !ok {
+			child = &routeNode{}
+			n.literals[seg] = child
+		}
+		n = child
+	}
+
+	if n.routes == nil {
+		n.routes = map[string][]*Route{}
+	}
+	// "/items/{id}" and "/items/{name}" share a node; the route with the
+	// stricter params is checked first
+	rs := append(n.routes[r.Method], r)
+	sort.SliceStable(rs, func(i, j int) bool {
+		return constrainedParams(rs[i]) > constrainedParams(rs[j])
+	})
+	n.routes[r.Method] = rs
+}
+
+// FindRoute matches path against the spec paths. Depending on the base path
+// mode the request may carry the spec base path (Swagger 2 basePath or
+// OpenAPI 3 servers) in front of the spec path.
+//
+// Path parameters must satisfy their declared schema. When the path exists
+// for other methods only, the error is a *MethodNotAllowedError; when it
+// only fails on a parameter schema, a *ParamError; otherwise ErrNoRoute.
+func (p *RouterProvider) FindRoute(method, path string) (*RouteMatch, error) {
+	method = strings.ToUpper(method)
+	candidates := p.candidatePaths(path)
+
+	var paramErr *ParamError
+	for _, candidate := range candidates {
+		m, perr := p.findRoute(method, candidate)
+		if m != nil {
+			return m, nil
+		}
+		if paramErr == nil {
+			paramErr = perr
+		}
+	}
+
+	if allowed := p.allowedMethods(candidates); len(allowed) > 0 {
+		return nil, &MethodNotAllowedError{Method: method, Path: path, Allowed: allowed}
+	}
+	if paramErr != nil {
+		paramErr.Method = method
+		paramErr.Path = path
+		return nil, paramErr
+	}
+	return nil, ErrNoRoute
+}
+
+// allowedMethods returns the sorted methods that have a route for any of
+// the candidate paths.
+func (p *RouterProvider) allowedMethods(candidates []string) []string {
+	seen := map[string]bool{}
+	for _, candidate := range candidates {
+		if !strings.HasPrefix(candidate, "/") {
 			continue
 		}
+		segs := splitPath(candidate)
+		for i, seg := range segs {
+			segs[i] = unescapeSegment(seg)
+		}
+		p.root.collectMethods(segs, 0, seen)
+	}
+	return sortedKeys(seen)
+}
 
-		score := p.routeSpecificityScore(r.Swagger)
-		if score > bestScore {
-			best = r
-			bestScore = score
+func (p *RouterProvider) candidatePaths(path string) []string {
+	if p.mode == config.BasePathIgnore || len(p.basePaths) == 0 {
+		return []string{path}
+	}
+
+	var out []string
+	for _, bp := range p.basePaths {
+		if rel, ok := stripBasePath(path, bp); ok {
+			out = append(out, rel)
 		}
 	}
+	if p.mode == config.BasePathAuto {
+		out = append(out, path)
+	}
+	return out
+}
+
+func (p *RouterProvider) findRoute(method, path string) (*RouteMatch, *ParamError) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, nil
+	}
+
+	segs := splitPath(path)
+	for i, seg := range segs {
+		segs[i] = unescapeSegment(seg)
+	}
 
-	return best
+	var perr *ParamError
+	r := p.root.find(method, segs, 0, &perr)
+	if r == nil {
+		return nil, perr
+	}
+
+	params := map[string]string{}
+	for i, seg := range r.segments {
+		if name, ok := pathParamName(seg); ok {
+			params[name] = segs[i]
+		}
+	}
+	return &RouteMatch{Route: r, Path: path, Params: params}, nil
 }
 
-func (p *RouterProvider) GetRoutes() []Route {
-	return p.routes
+// unescapeSegment percent-decodes one path segment. Segments are split
+// before decoding, so an encoded "%2F" stays inside its segment. Malformed
+// escapes are kept as they are.
+func unescapeSegment(seg string) string {
+	if !strings.Contains(seg, "%") {
+		return seg
+	}
+	if dec, err := url.PathUnescape(seg); err == nil {
+		return dec
+	}
+	return seg
 }
 
-func (p *RouterProvider) routeSpecificityScore(swaggerPath string) int {
-	parts := strings.Split(strings.Trim(swaggerPath, "/"), "/")
-	score := 0
-	for _, p := range parts {
-		if strings.HasPrefix(p, "{") && strings.HasSuffix(p, "}") {
-			score += 0 // param segment
-		} else {
-			score += 10
+// find walks the trie depth first, literal children before the param child,
+// and backtracks when a branch has no route for method or its path params
+// do not fit their schema. The first param mismatch is kept in perr.
+func (n *routeNode) find(method string, segs []string, depth int, perr **ParamError) *Route {
+	if depth == len(segs) {
+		for _, r := range n.routes[method] {
+			err := r.checkParams(segs)
+			if err == nil {
+				return r
+			}
+			if *perr == nil {
+				*perr = err
+			}
 		}
+		return nil
 	}
 
-	score += len(parts)
-	return score
+	seg := segs[depth]
+	if child, ok := n.literals[seg]; ok {
+		if r := child.find(method, segs, depth+1, perr); r != nil {
+			return r
+		}
+	}
+	if n.param != nil && seg != "" {
+		return n.param.find(method, segs, depth+1, perr)
+	}
+	return nil
 }
 
-func swaggerPathToSampleName(method, swaggerPath string) string {
-	s := strings.TrimPrefix(swaggerPath, "/")
-	s = strings.ReplaceAll(s, "/", "_")
-	return fmt.Sprintf("%s__%s.json", strings.ToUpper(method), s)
+// collectMethods adds the methods of every route matching segs, on any
+// branch, to out.
+func (n *routeNode) collectMethods(segs []string, depth int, out map[string]bool) {
+	if depth == len(segs) {
+		for method, rs := range n.routes {
+			for _, r := range rs {
+				if r.checkParams(segs) == nil {
+					out[method] = true
+					break
+				}
+			}
+		}
+		return
+	}
+
+	seg := segs[depth]
+	if child, ok := n.literals[seg]; ok {
+		child.collectMethods(segs, depth+1, out)
+	}
+	if n.param != nil && seg != "" {
+		n.param.collectMethods(segs, depth+1, out)
+	}
+}
+
+func (p *RouterProvider) GetRoutes() []Route {
+	return p.routes
 }
 
-func swaggerPathToRegex(swaggerPath string) *regexp.Regexp {
-	parts := strings.Split(swaggerPath, "/")
+// splitPath splits a path into segments. A single trailing slash is ignored.
+func splitPath(path string) []string {
+	path = strings.TrimPrefix(path, "/")
+	path = strings.TrimSuffix(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+// specBasePaths returns the base paths declared by the spec, longest first.
+// Swagger 2 uses basePath; OpenAPI 3 uses the path of every server URL, with
+// server variables expanded to their default and enum values.
+func specBasePaths(spec *Spec) []string {
+	var raw []string
+	switch {
+	case spec.Doc2 != nil:
+		raw = append(raw, spec.Doc2.BasePath)
+	case spec.Doc3 != nil:
+		for _, srv := range spec.Doc3.Servers {
+			if srv == nil {
+				continue
+			}
+			for _, u := range expandServerURL(srv) {
+				raw = append(raw, serverURLPath(u))
+			}
+		}
+	}
+
+	seen := map[string]bool{}
 	var out []string
+	for _, bp := range raw {
+		bp = "/" + strings.Trim(bp, "/")
+		if bp == "/" || seen[bp] {
+			continue
+		}
+		seen[bp] = true
+		out = append(out, bp)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool { return len(out[i]) > len(out[j]) })
+	return out
+}
+
+// expandServerURL substitutes server variables with their default and enum
+// values and returns every resulting URL.
+func expandServerURL(srv *openapi3.Server) []string {
+	urls := []string{srv.URL}
 
-	for _, p := range parts {
-		if p == "" {
+	names := make([]string, 0, len(srv.Variables))
+	for name := range srv.Variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		v := srv.Variables[name]
+		if v == nil {
 			continue
 		}
-		if strings.HasPrefix(p, "{") && strings.HasSuffix(p, "}") {
-			out = append(out, `([^/]+)`)
-		} else {
-			out = append(out, regexp.QuoteMeta(p))
+
+		values := []string{v.Default}
+		for _, e := range v.Enum {
+			if e != v.Default {
+				values = append(values, e)
+			}
+		}
+
+		var next []string
+		for _, u := range urls {
+			for _, val := range values {
+				next = append(next, strings.ReplaceAll(u, "{"+name+"}", val))
+			}
 		}
+		urls = next
 	}
 
-	pat := "^/" + strings.Join(out, "/") + "/?$"
-	return regexp.MustCompile(pat)
+	return urls
+}
+
+func serverURLPath(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	return u.Path
+}
+
+func stripBasePath(path, basePath string) (string, bool) {
+	if path == basePath {
+		return "/", true
+	}
+	if strings.HasPrefix(path, basePath+"/") {
+		return strings.TrimPrefix(path, basePath), true
+	}
+	return "", false
+}
+
+func swaggerPathToSampleName(method, swaggerPath string) string {
+	s := strings.TrimPrefix(swaggerPath, "/")
+	s = strings.ReplaceAll(s, "/", "_")
+	return fmt.Sprintf("%s__%s.json", strings.ToUpper(method), s)
 }
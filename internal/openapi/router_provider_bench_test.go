@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openapi
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// linearRouter is the previous router: one regex per route, scanned in
+// order, with the specificity score computed on every request. It is kept
+// as the baseline for the benchmarks below.
+type linearRouter struct {
+	routes []linearRoute
+}
+
+type linearRoute struct {
+	Route
+	re *regexp.Regexp
+}
+
+func newLinearRouter(routes []Route) *linearRouter {
+	lr := &linearRouter{}
+	for _, r := range routes {
+		lr.routes = append(lr.routes, linearRoute{Route: r, re: swaggerPathToRegex(r.Swagger)})
+	}
+	return lr
+}
+
+func (p *linearRouter) FindRoute(method, path string) *Route {
+	method = strings.ToUpper(method)
+
+	var best *Route
+	bestScore := -1
+	for i := range p.routes {
+		r := &p.routes[i]
+		if r.Method != method || !r.re.MatchString(path) {
+			continue
+		}
+		if score := routeSpecificityScore(r.Swagger); score > bestScore {
+			best = &r.Route
+			bestScore = score
+		}
+	}
+	return best
+}
+
+func routeSpecificityScore(swaggerPath string) int {
+	parts := strings.Split(strings.Trim(swaggerPath, "/"), "/")
+	score := 0
+	for _, p := range parts {
+		if _, ok := pathParamName(p); !ok {
+			score += 10
+		}
+	}
+	return score + len(parts)
+}
+
+func swaggerPathToRegex(swaggerPath string) *regexp.Regexp {
+	var out []string
+	for _, p := range strings.Split(swaggerPath, "/") {
+		if p == "" {
+			continue
+		}
+		if _, ok := pathParamName(p); ok {
+			out = append(out, `([^/]+)`)
+		} else {
+			out = append(out, regexp.QuoteMeta(p))
+		}
+	}
+	return regexp.MustCompile("^/" + strings.Join(out, "/") + "/?$")
+}
+
+// benchRoutes builds about 900 operations shaped like a large REST API:
+// 100 resources with collection, item and sub-resource paths.
+func benchRoutes() ([]Route, []string) {
+	var routes []Route
+	var paths []string
+	for i := 0; i < 100; i++ {
+		res := fmt.Sprintf("/api/v1/resource%d", i)
+		for _, tpl := range []struct {
+			path    string
+			methods []string
+		}{
+			{res, []string{"GET", "POST"}},
+			{res + "/{id}", []string{"GET", "PUT", "DELETE"}},
+			{res + "/{id}/status", []string{"GET"}},
+			{res + "/{id}/items/{itemId}", []string{"GET", "DELETE"}},
+			{res + "/preferences", []string{"GET"}},
+		} {
+			for _, m := range tpl.methods {
+				routes = append(routes, Route{Method: m, Swagger: tpl.path})
+			}
+		}
+		paths = append(paths,
+			res,
+			res+"/42",
+			res+"/42/status",
+			res+"/42/items/7",
+			res+"/preferences",
+		)
+	}
+	return routes, paths
+}
+
+func TestLinearRouter_AgreesWithTrie(t *testing.T) {
+	routes, paths := benchRoutes()
+	trie := newRouterProvider(append([]Route(nil), routes...))
+	linear := newLinearRouter(routes)
+
+	for _, path := range paths {
+		want := linear.FindRoute("GET", path)
+		got, _ := trie.FindRoute("GET", path)
+		if want == nil || got == nil || want.Swagger != got.Route.Swagger {
+			t.Fatalf("%s: linear=%v trie=%v", path, want, got)
+		}
+	}
+}
+
+func BenchmarkFindRoute_Trie(b *testing.B) {
+	routes, paths := benchRoutes()
+	p := newRouterProvider(routes)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if m, _ := p.FindRoute("GET", paths[i%len(paths)]); m == nil {
+			b.Fatal("no match")
+		}
+	}
+}
+
+func BenchmarkFindRoute_Linear(b *testing.B) {
+	routes, paths := benchRoutes()
+	p := newLinearRouter(routes)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if p.FindRoute("GET", paths[i%len(paths)]) == nil {
+			b.Fatal("no match")
+		}
+	}
+}
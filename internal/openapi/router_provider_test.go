@@ -7,36 +7,108 @@ package openapi
 import (
 	"testing"
 
+	"github.com/getkin/kin-openapi/openapi2"
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/ozgen/openapi-emulator/config"
 )
 
-func TestSwaggerPathToRegex_Static(t *testing.T) {
-	re := swaggerPathToRegex("/v1/health")
+func TestRouterProvider_FindRoute_Static(t *testing.T) {
+	p := newRouterProvider([]Route{{Method: "GET", Swagger: "/v1/health"}})
 
 	ok := []string{"/v1/health", "/v1/health/"}
-	for _, p := range ok {
-		if !re.MatchString(p) {
-			t.Fatalf("expected %q to match %q", re.String(), p)
+	for _, path := range ok {
+		if m, _ := p.FindRoute("GET", path); m == nil {
+			t.Fatalf("expected /v1/health to match %q", path)
 		}
 	}
 
-	bad := []string{"/v1/health/x", "/v1/healt", "/v1/health//"}
-	for _, p := range bad {
-		if re.MatchString(p) {
-			t.Fatalf("expected %q to NOT match %q", re.String(), p)
+	bad := []string{"/v1/health/x", "/v1/healt", "/v1/health//", "v1/health"}
+	for _, path := range bad {
+		if m, _ := p.FindRoute("GET", path); m != nil {
+			t.Fatalf("expected /v1/health to NOT match %q, got %#v", path, m)
 		}
 	}
 }
 
-func TestSwaggerPathToRegex_Param(t *testing.T) {
-	re := swaggerPathToRegex("/users/{id}")
+func TestRouterProvider_FindRoute_Param(t *testing.T) {
+	p := newRouterProvider([]Route{{Method: "GET", Swagger: "/users/{id}"}})
 
-	if !re.MatchString("/users/123") {
+	m, _ := p.FindRoute("GET", "/users/123")
+	if m == nil {
 		t.Fatalf("expected match")
 	}
-	if re.MatchString("/users/123/profile") {
+	if m.Params["id"] != "123" {
+		t.Fatalf("expected id=123, got %#v", m.Params)
+	}
+	if m, _ := p.FindRoute("GET", "/users/123/profile"); m != nil {
 		t.Fatalf("expected no match")
 	}
+	if m, _ := p.FindRoute("GET", "/users//"); m != nil {
+		t.Fatalf("expected empty param segment to not match")
+	}
+}
+
+func TestRouterProvider_FindRoute_LiteralBeatsParam(t *testing.T) {
+	p := newRouterProvider([]Route{
+		{Method: "GET", Swagger: "/scans/{id}"},
+		{Method: "GET", Swagger: "/scans/preferences"},
+		{Method: "DELETE", Swagger: "/scans/{id}"},
+		{Method: "GET", Swagger: "/scans/{id}/results/{rid}"},
+	})
+
+	if m, _ := p.FindRoute("GET", "/scans/preferences"); m == nil || m.Route.Swagger != "/scans/preferences" {
+		t.Fatalf("expected literal route, got %#v", m)
+	}
+	if m, _ := p.FindRoute("GET", "/scans/abc"); m == nil || m.Route.Swagger != "/scans/{id}" {
+		t.Fatalf("expected param route, got %#v", m)
+	}
+
+	// backtracks to the param route when the literal one lacks the method
+	m, _ := p.FindRoute("DELETE", "/scans/preferences")
+	if m == nil || m.Route.Swagger != "/scans/{id}" || m.Params["id"] != "preferences" {
+		t.Fatalf("expected DELETE /scans/{id}, got %#v", m)
+	}
+
+	m, _ = p.FindRoute("GET", "/scans/1/results/2")
+	if m == nil || m.Params["id"] != "1" || m.Params["rid"] != "2" {
+		t.Fatalf("expected both params, got %#v", m)
+	}
+}
+
+func TestRouterProvider_FindRoute_DecodesParams(t *testing.T) {
+	p := newRouterProvider([]Route{
+		{Method: "GET", Swagger: "/files/{name}"},
+		{Method: "GET", Swagger: "/files/{name}/meta"},
+		{Method: "GET", Swagger: "/tags/a b"},
+	})
+
+	m, _ := p.FindRoute("GET", "/files/a%2Fb%20c")
+	if m == nil || m.Route.Swagger != "/files/{name}" {
+		t.Fatalf("expected /files/{name}, got %#v", m)
+	}
+	if m.Params["name"] != "a/b c" {
+		t.Fatalf("expected decoded name, got %q", m.Params["name"])
+	}
+	if m.Path != "/files/a%2Fb%20c" {
+		t.Fatalf("expected path as received, got %q", m.Path)
+	}
+
+	if m, _ := p.FindRoute("GET", "/files/x%2Fy/meta"); m == nil || m.Params["name"] != "x/y" {
+		t.Fatalf("expected encoded slash to stay in its segment, got %#v", m)
+	}
+	if m, _ := p.FindRoute("GET", "/tags/a%20b"); m == nil {
+		t.Fatalf("expected literal segment to match decoded")
+	}
+	if m, _ := p.FindRoute("GET", "/files/100%"); m == nil || m.Params["name"] != "100%" {
+		t.Fatalf("expected malformed escape kept as is, got %#v", m)
+	}
+}
+
+func TestRouterProvider_FindRoute_Root(t *testing.T) {
+	p := newRouterProvider([]Route{{Method: "GET", Swagger: "/"}})
+	if m, _ := p.FindRoute("GET", "/"); m == nil {
+		t.Fatalf("expected root route")
+	}
 }
 
 func TestSwaggerPathToSampleName(t *testing.T) {
@@ -48,29 +120,25 @@ func TestSwaggerPathToSampleName(t *testing.T) {
 }
 
 func TestRouterProvider_FindRoute(t *testing.T) {
-	p := &RouterProvider{
-		routes: []Route{
-			{
-				Method:     "GET",
-				Swagger:    "/users/{id}",
-				Regex:      swaggerPathToRegex("/users/{id}"),
-				SampleFile: "GET__users_{id}.json",
-			},
-			{
-				Method:     "POST",
-				Swagger:    "/users",
-				Regex:      swaggerPathToRegex("/users"),
-				SampleFile: "POST__users.json",
-			},
+	p := newRouterProvider([]Route{
+		{
+			Method:     "GET",
+			Swagger:    "/users/{id}",
+			SampleFile: "GET__users_{id}.json",
 		},
-	}
+		{
+			Method:     "POST",
+			Swagger:    "/users",
+			SampleFile: "POST__users.json",
+		},
+	})
 
-	r := p.FindRoute("get", "/users/55")
-	if r == nil || r.Swagger != "/users/{id}" {
-		t.Fatalf("expected to find /users/{id}, got %#v", r)
+	m, _ := p.FindRoute("get", "/users/55")
+	if m == nil || m.Route.Swagger != "/users/{id}" {
+		t.Fatalf("expected to find /users/{id}, got %#v", m)
 	}
 
-	if p.FindRoute("GET", "/nope") != nil {
+	if m, _ := p.FindRoute("GET", "/nope"); m != nil {
 		t.Fatalf("expected nil")
 	}
 }
@@ -87,7 +155,7 @@ func TestNewRouterProvider_BuildRoutes(t *testing.T) {
 	doc := &openapi3.T{Paths: paths}
 	spec := &Spec{Doc3: doc}
 
-	provider := NewRouterProvider(spec)
+	provider := NewRouterProvider(spec, RouterConfig{})
 	if provider == nil {
 		t.Fatalf("expected provider, got nil")
 	}
@@ -104,8 +172,8 @@ func TestNewRouterProvider_BuildRoutes(t *testing.T) {
 			if r.SampleFile != "GET__users_{id}.json" {
 				t.Fatalf("bad sample file: %q", r.SampleFile)
 			}
-			if !r.Regex.MatchString("/users/1") {
-				t.Fatalf("regex should match")
+			if m, _ := rp.FindRoute("GET", "/users/1"); m == nil || m.Route.Swagger != r.Swagger {
+				t.Fatalf("route should match")
 			}
 		}
 		if r.Method == "POST" && r.Swagger == "/users" {
@@ -113,8 +181,8 @@ func TestNewRouterProvider_BuildRoutes(t *testing.T) {
 			if r.SampleFile != "POST__users.json" {
 				t.Fatalf("bad sample file: %q", r.SampleFile)
 			}
-			if !r.Regex.MatchString("/users") {
-				t.Fatalf("regex should match")
+			if m, _ := rp.FindRoute("POST", "/users"); m == nil || m.Route.Swagger != r.Swagger {
+				t.Fatalf("route should match")
 			}
 		}
 	}
@@ -125,26 +193,24 @@ func TestNewRouterProvider_BuildRoutes(t *testing.T) {
 }
 
 func TestNewRouterProvider_NilGuards(t *testing.T) {
-	if got := NewRouterProvider(nil); got != nil {
+	if got := NewRouterProvider(nil, RouterConfig{}); got != nil {
 		t.Fatalf("expected nil, got %#v", got)
 	}
 
-	if got := NewRouterProvider(&Spec{Doc3: nil}); got != nil {
+	if got := NewRouterProvider(&Spec{Doc3: nil}, RouterConfig{}); got != nil {
 		t.Fatalf("expected nil, got %#v", got)
 	}
 
-	if got := NewRouterProvider(&Spec{Doc3: &openapi3.T{}}); got != nil {
+	if got := NewRouterProvider(&Spec{Doc3: &openapi3.T{}}, RouterConfig{}); got != nil {
 		t.Fatalf("expected nil, got %#v", got)
 	}
 }
 
 func TestRouterProvider_GetRoutes_ReturnsRoutes(t *testing.T) {
-	p := &RouterProvider{
-		routes: []Route{
-			{Method: "GET", Swagger: "/x", Regex: swaggerPathToRegex("/x"), SampleFile: "GET__x.json"},
-			{Method: "POST", Swagger: "/y", Regex: swaggerPathToRegex("/y"), SampleFile: "POST__y.json"},
-		},
-	}
+	p := newRouterProvider([]Route{
+		{Method: "POST", Swagger: "/y", SampleFile: "POST__y.json"},
+		{Method: "GET", Swagger: "/x", SampleFile: "GET__x.json"},
+	})
 
 	got := p.GetRoutes()
 	if len(got) != 2 {
@@ -154,3 +220,92 @@ func TestRouterProvider_GetRoutes_ReturnsRoutes(t *testing.T) {
 		t.Fatalf("unexpected routes: %#v", got)
 	}
 }
+
+func basePathSpec(t *testing.T, doc2BasePath string, servers openapi3.Servers) *Spec {
+	t.Helper()
+
+	paths := openapi3.NewPaths()
+	paths.Set("/scans/{id}", &openapi3.PathItem{
+		Get: &openapi3.Operation{Responses: openapi3.NewResponses()},
+	})
+
+	spec := &Spec{Doc3: &openapi3.T{Paths: paths, Servers: servers}}
+	if doc2BasePath != "" {
+		spec.Doc2 = &openapi2.T{BasePath: doc2BasePath}
+	}
+	return spec
+}
+
+func TestRouterProvider_Swagger2BasePath_Modes(t *testing.T) {
+	spec := basePathSpec(t, "/api/v2/", nil)
+
+	cases := []struct {
+		mode       config.BasePathMode
+		withPrefix bool
+		without    bool
+	}{
+		{config.BasePathAuto, true, true},
+		{config.BasePathRequired, true, false},
+		{config.BasePathIgnore, false, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(string(tc.mode), func(t *testing.T) {
+			p := NewRouterProvider(spec, RouterConfig{BasePathMode: tc.mode})
+
+			r, _ := p.FindRoute("GET", "/api/v2/scans/1")
+			if (r != nil) != tc.withPrefix {
+				t.Fatalf("with prefix: expected match=%v, got %#v", tc.withPrefix, r)
+			}
+			if r != nil && r.Route.Swagger != "/scans/{id}" {
+				t.Fatalf("sample lookup must stay on spec path, got %q", r.Route.Swagger)
+			}
+
+			r, _ = p.FindRoute("GET", "/scans/1")
+			if (r != nil) != tc.without {
+				t.Fatalf("without prefix: expected match=%v, got %#v", tc.without, r)
+			}
+		})
+	}
+}
+
+func TestRouterProvider_ServersWithVariables(t *testing.T) {
+	spec := basePathSpec(t, "", openapi3.Servers{
+		{URL: "https://example.com/openvasd"},
+		{
+			URL: "{scheme}://{host}/{tenant}/api",
+			Variables: map[string]*openapi3.ServerVariable{
+				"scheme": {Default: "https"},
+				"host":   {Default: "localhost"},
+				"tenant": {Default: "default", Enum: []string{"default", "acme"}},
+			},
+		},
+		{URL: "/"},
+	})
+
+	p := NewRouterProvider(spec, RouterConfig{BasePathMode: config.BasePathRequired})
+
+	for _, path := range []string{"/openvasd/scans/1", "/default/api/scans/1", "/acme/api/scans/1"} {
+		if r, _ := p.FindRoute("GET", path); r == nil || r.Route.Swagger != "/scans/{id}" {
+			t.Fatalf("expected %q to match /scans/{id}, got %#v", path, r)
+		}
+	}
+	if r, _ := p.FindRoute("GET", "/other/api/scans/1"); r != nil {
+		t.Fatalf("expected no match for unknown tenant, got %#v", r)
+	}
+}
+
+func TestSpecBasePaths_LongestFirstAndDeduplicated(t *testing.T) {
+	spec := basePathSpec(t, "", openapi3.Servers{
+		{URL: "/a"},
+		{URL: "/a/b/"},
+		{URL: "https://x.example.com/a"},
+		{URL: "https://x.example.com"},
+	})
+
+	got := specBasePaths(spec)
+	want := []string{"/a/b", "/a"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("got %v want %v", got, want)
+	}
+}
@@ -0,0 +1,297 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openapi
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+var (
+	fakeFirstNames = []string{"Alice", "Bruno", "Chen", "Dana", "Emil", "Fatima", "Greta", "Hiro", "Ines", "Jonas", "Kofi", "Lena"}
+	fakeLastNames  = []string{"Schmidt", "Garcia", "Nguyen", "Kowalski", "Okafor", "Larsen", "Rossi", "Tanaka", "Meyer", "Silva"}
+	fakeWords      = []string{"alpha", "network", "nightly", "scan", "report", "target", "policy", "asset", "agent", "audit", "cluster", "gateway", "backup", "office", "staging", "edge"}
+	fakeCities     = []string{"Berlin", "Osnabrück", "Lisbon", "Toronto", "Nairobi", "Osaka", "Denver", "Oslo"}
+	fakeCountries  = []string{"Germany", "Portugal", "Canada", "Kenya", "Japan", "United States", "Norway"}
+	fakeStatuses   = []string{"active", "pending", "running", "done", "failed"}
+	fakeColors     = []string{"red", "green", "blue", "orange", "purple"}
+
+	// fakeEpoch is the start of the range of generated dates.
+	fakeEpoch = time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// MixSeed combines a configured seed with the request method and path, so
+// the same request always gets the same generated body.
+func MixSeed(seed, method, path string) uint64 {
+	h := fnv.New64a()
+	for _, part := range []string{seed, strings.ToUpper(method), path} {
+		_, _ = h.Write([]byte(part))
+		_, _ = h.Write([]byte{0})
+	}
+	return h.Sum64()
+}
+
+func newRand(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))
+}
+
+func (g *schemaGenerator) pick(values []string) string {
+	return values[g.rnd.IntN(len(values))]
+}
+
+// fakeString returns a realistic string for s. Patterns and formats come
+// first; plain strings are derived from the property name.
+func (g *schemaGenerator) fakeString(s *openapi3.Schema, name string) string {
+	if s.Pattern != "" {
+		if v, ok := patternString(s.Pattern, s.MinLength, s.MaxLength); ok {
+			return v
+		}
+	}
+	if v, ok := g.fakeFormat(s.Format); ok {
+		return v
+	}
+	if v, ok := formatValues[s.Format]; ok {
+		return v
+	}
+	return fitLength(g.nameString(name), s.MinLength, s.MaxLength)
+}
+
+func (g *schemaGenerator) fakeFormat(format string) (string, bool) {
+	switch format {
+	case "date-time":
+		return g.fakeTime().Format(time.RFC3339), true
+	case "date":
+		return g.fakeTime().Format(time.DateOnly), true
+	case "time":
+		return g.fakeTime().Format(time.TimeOnly), true
+	case "uuid":
+		return g.fakeUUID(), true
+	case "email", "idn-email":
+		return g.fakeEmail(), true
+	case "uri", "url", "iri":
+		return g.fakeURL(), true
+	case "hostname", "idn-hostname":
+		return g.pick(fakeWords) + ".example.com", true
+	case "ipv4":
+		return fmt.Sprintf("10.%d.%d.%d", g.rnd.IntN(256), g.rnd.IntN(256), 1+g.rnd.IntN(254)), true
+	case "ipv6":
+		return fmt.Sprintf("2001:db8::%x", 1+g.rnd.IntN(0xffff)), true
+	}
+	return "", false
+}
+
+// nameString derives a string from a property name such as "email",
+// "createdAt" or "ownerName".
+func (g *schemaGenerator) nameString(name string) string {
+	words := nameWords(name)
+	has := func(w ...string) bool {
+		for _, want := range w {
+			for _, got := range words {
+				if got == want {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	last := ""
+	if len(words) > 0 {
+		last = words[len(words)-1]
+	}
+
+	switch {
+	case has("email", "mail"):
+		return g.fakeEmail()
+	case last == "id" || last == "uuid" || last == "guid":
+		return g.fakeUUID()
+	case has("first", "given") && last == "name":
+		return g.pick(fakeFirstNames)
+	case (has("last", "family") && last == "name") || has("surname"):
+		return g.pick(fakeLastNames)
+	case has("username", "login") || (has("user") && last == "name"):
+		return strings.ToLower(g.pick(fakeFirstNames) + "." + g.pick(fakeLastNames))
+	case has("owner", "author", "creator", "person", "contact", "user", "fullname"):
+		return g.pick(fakeFirstNames) + " " + g.pick(fakeLastNames)
+	case has("url", "uri", "link", "href", "website"):
+		return g.fakeURL()
+	case has("host", "hostname", "domain"):
+		return g.pick(fakeWords) + ".example.com"
+	case has("ip"):
+		v, _ := g.fakeFormat("ipv4")
+		return v
+	case has("city"):
+		return g.pick(fakeCities)
+	case has("country"):
+		return g.pick(fakeCountries)
+	case has("phone", "mobile", "fax"):
+		return fmt.Sprintf("+1-555-01%02d", g.rnd.IntN(100))
+	case last == "name" || last == "title" || last == "label":
+		return capitalize(g.pick(fakeWords)) + " " + capitalize(g.pick(fakeWords))
+	case has("description", "comment", "summary", "message", "note", "notes", "details"):
+		return g.sentence()
+	case has("status", "state"):
+		return g.pick(fakeStatuses)
+	case has("version"):
+		return fmt.Sprintf("%d.%d.%d", 1+g.rnd.IntN(5), g.rnd.IntN(20), g.rnd.IntN(10))
+	case has("color", "colour"):
+		return g.pick(fakeColors)
+	case has("token", "key", "secret", "hash", "checksum"):
+		return fmt.Sprintf("%016x%016x", g.rnd.Uint64(), g.rnd.Uint64())
+	case last == "at" || has("date", "time", "timestamp"):
+		return g.fakeTime().Format(time.RFC3339)
+	}
+	return g.pick(fakeWords)
+}
+
+func (g *schemaGenerator) fakeTime() time.Time {
+	return fakeEpoch.Add(time.Duration(g.rnd.Int64N(365*24*3600)) * time.Second)
+}
+
+func (g *schemaGenerator) fakeUUID() string {
+	hi, lo := g.rnd.Uint64(), g.rnd.Uint64()
+	hi = hi&^0xf000 | 0x4000     // version 4
+	lo = lo&^(0xc<<60) | 0x8<<60 // RFC 4122 variant
+	return fmt.Sprintf("%08x-%04x-%04x-%04x-%012x", hi>>32, hi>>16&0xffff, hi&0xffff, lo>>48, lo&0xffffffffffff)
+}
+
+func (g *schemaGenerator) fakeEmail() string {
+	return strings.ToLower(g.pick(fakeFirstNames)+"."+g.pick(fakeLastNames)) + "@example.com"
+}
+
+func (g *schemaGenerator) fakeURL() string {
+	return fmt.Sprintf("https://example.com/%s/%d", g.pick(fakeWords), 1+g.rnd.IntN(999))
+}
+
+func (g *schemaGenerator) sentence() string {
+	words := make([]string, 4+g.rnd.IntN(5))
+	for i := range words {
+		words[i] = g.pick(fakeWords)
+	}
+	return capitalize(strings.Join(words, " ")) + "."
+}
+
+// fakeInteger returns a random integer within the bounds of s, preferring
+// a range that fits the property name.
+func (g *schemaGenerator) fakeInteger(s *openapi3.Schema, name string) int {
+	lo, hi, step := intBounds(s)
+	a, b := nameRange(name)
+	a, b = narrowRange(a, b, lo, hi)
+
+	first, last := math.Ceil(a/step), math.Floor(b/step)
+	if first > last {
+		return genInteger(s)
+	}
+	return int((first + float64(g.rnd.Int64N(int64(last-first)+1))) * step)
+}
+
+// fakeNumber returns a random number with two decimals within the bounds
+// of s. Numbers with a multipleOf keep the fixed value.
+func (g *schemaGenerator) fakeNumber(s *openapi3.Schema, name string) float64 {
+	if s.MultipleOf != nil {
+		return genNumber(s)
+	}
+	lo, hi := math.Inf(-1), math.Inf(1)
+	if s.Min != nil {
+		lo = *s.Min
+	}
+	if s.Max != nil {
+		hi = *s.Max
+	}
+	a, b := nameRange(name)
+	a, b = narrowRange(a, b, lo, hi)
+
+	v := math.Round((a+g.rnd.Float64()*(b-a))*100) / 100
+	if v < lo || v > hi || (s.ExclusiveMin && v == lo) || (s.ExclusiveMax && v == hi) {
+		return genNumber(s)
+	}
+	return v
+}
+
+// nameRange returns a plausible range for a numeric property name.
+func nameRange(name string) (float64, float64) {
+	for _, w := range nameWords(name) {
+		switch w {
+		case "age":
+			return 18, 90
+		case "port":
+			return 1024, 65535
+		case "year":
+			return 2000, 2030
+		case "percent", "percentage", "progress":
+			return 0, 100
+		case "score", "rating", "severity":
+			return 0, 10
+		case "latitude", "lat":
+			return -90, 90
+		case "longitude", "lon", "lng":
+			return -180, 180
+		case "count", "total", "size", "length", "quantity", "amount", "limit":
+			return 0, 100
+		}
+	}
+	return 1, 1000
+}
+
+// narrowRange intersects the preferred range [a, b] with the bounds
+// [lo, hi], keeping a finite range of the bounds when they do not overlap.
+func narrowRange(a, b, lo, hi float64) (float64, float64) {
+	a, b = math.Max(a, lo), math.Min(b, hi)
+	if a <= b {
+		return a, b
+	}
+	a, b = lo, hi
+	if math.IsInf(a, -1) {
+		a = b - 100
+	}
+	if math.IsInf(b, 1) {
+		b = a + 100
+	}
+	return a, b
+}
+
+// nameWords splits a camelCase, snake_case or kebab-case name into lower
+// case words.
+func nameWords(name string) []string {
+	var (
+		words []string
+		cur   []rune
+	)
+	flush := func() {
+		if len(cur) > 0 {
+			words = append(words, string(cur))
+			cur = nil
+		}
+	}
+	prevUpper := false
+	for _, r := range name {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && !prevUpper:
+			flush()
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			cur = append(cur, unicode.ToLower(r))
+		}
+		prevUpper = unicode.IsUpper(r)
+	}
+	flush()
+	return words
+}
+
+func capitalize(s string) string {
+	r := []rune(s)
+	if len(r) > 0 {
+		r[0] = unicode.ToUpper(r[0])
+	}
+	return string(r)
+}
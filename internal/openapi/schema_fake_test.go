@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openapi
+
+import (
+	"encoding/json"
+	"net/mail"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// fakeSchema mixes named, formatted and constrained properties.
+func fakeSchema() *openapi3.Schema {
+	str := func(mod func(s *openapi3.Schema)) *openapi3.SchemaRef {
+		s := openapi3.NewStringSchema()
+		mod(s)
+		return &openapi3.SchemaRef{Value: s}
+	}
+	typed := func(typ string, mod func(s *openapi3.Schema)) *openapi3.SchemaRef {
+		s := &openapi3.Schema{Type: &openapi3.Types{typ}}
+		mod(s)
+		return &openapi3.SchemaRef{Value: s}
+	}
+	none := func(*openapi3.Schema) {}
+
+	return &openapi3.Schema{
+		Type: &openapi3.Types{"object"},
+		Properties: openapi3.Schemas{
+			"id":          str(none),
+			"email":       str(none),
+			"ownerName":   str(func(s *openapi3.Schema) { s.MaxLength = uint64p(6) }),
+			"description": str(func(s *openapi3.Schema) { s.MinLength = 80 }),
+			"createdAt":   str(func(s *openapi3.Schema) { s.Format = "date-time" }),
+			"hostIp":      str(func(s *openapi3.Schema) { s.Format = "ipv4" }),
+			"code":        str(func(s *openapi3.Schema) { s.Pattern = `^[A-Z]{3}-\d{2}$` }),
+			"kind":        {Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Enum: []any{"a", "b", "c"}}},
+			"count":       typed("integer", none),
+			"port":        typed("integer", func(s *openapi3.Schema) { s.Max = float(2000) }),
+			"small":       typed("integer", func(s *openapi3.Schema) { s.Min = float(-10); s.Max = float(-5) }),
+			"step":        typed("integer", func(s *openapi3.Schema) { s.Min = float(10); s.MultipleOf = float(7) }),
+			"score":       typed("number", func(s *openapi3.Schema) { s.Min = float(0); s.ExclusiveMin = true }),
+			"ratio":       typed("number", func(s *openapi3.Schema) { s.Max = float(1); s.ExclusiveMax = true }),
+			"enabled":     typed("boolean", none),
+			"tags": typed("array", func(s *openapi3.Schema) {
+				s.MinItems, s.MaxItems = 2, uint64p(3)
+				s.Items = str(none)
+			}),
+		},
+	}
+}
+
+func fakeGenerated(t *testing.T, sch *openapi3.Schema, seed uint64) map[string]any {
+	t.Helper()
+
+	g := &schemaGenerator{rnd: newRand(seed)}
+	b, err := json.Marshal(g.genFromSchemaRef(&openapi3.SchemaRef{Value: sch}, map[string]bool{}, 0))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var m map[string]any
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	return m
+}
+
+func TestFakeValues_ValidateAndFitNames(t *testing.T) {
+	sch := fakeSchema()
+
+	for seed := uint64(0); seed < 50; seed++ {
+		m := fakeGenerated(t, sch, seed)
+		if err := sch.VisitJSON(m, openapi3.EnableFormatValidation()); err != nil {
+			t.Fatalf("seed %d: generated %#v does not validate: %v", seed, m, err)
+		}
+
+		if id, _ := m["id"].(string); !uuidPattern.MatchString(id) {
+			t.Fatalf("seed %d: expected uuid id, got %q", seed, id)
+		}
+		if email, _ := m["email"].(string); email == "" {
+			t.Fatalf("seed %d: missing email", seed)
+		} else if _, err := mail.ParseAddress(email); err != nil {
+			t.Fatalf("seed %d: invalid email %q", seed, email)
+		}
+		if at, _ := m["createdAt"].(string); at == "" {
+			t.Fatalf("seed %d: missing createdAt", seed)
+		} else if _, err := time.Parse(time.RFC3339, at); err != nil {
+			t.Fatalf("seed %d: invalid createdAt %q", seed, at)
+		}
+		if port := m["port"].(float64); port < 1024 {
+			t.Fatalf("seed %d: expected port range, got %v", seed, port)
+		}
+	}
+}
+
+func TestFakeValues_ReproducibleAndVaried(t *testing.T) {
+	sch := fakeSchema()
+
+	if a, b := fakeGenerated(t, sch, 42), fakeGenerated(t, sch, 42); !reflect.DeepEqual(a, b) {
+		t.Fatalf("expected the same body for the same seed:\n%v\n%v", a, b)
+	}
+	if a, b := fakeGenerated(t, sch, 1), fakeGenerated(t, sch, 2); reflect.DeepEqual(a, b) {
+		t.Fatalf("expected different bodies for different seeds: %v", a)
+	}
+}
+
+func TestFakeValues_ArrayLengthsVary(t *testing.T) {
+	sch := &openapi3.Schema{
+		Type:  &openapi3.Types{"array"},
+		Items: &openapi3.SchemaRef{Value: openapi3.NewStringSchema()},
+	}
+
+	lengths := map[int]bool{}
+	for seed := uint64(0); seed < 20; seed++ {
+		g := &schemaGenerator{rnd: newRand(seed)}
+		lengths[len(g.genFromSchemaRef(&openapi3.SchemaRef{Value: sch}, map[string]bool{}, 0).([]any))] = true
+	}
+	if len(lengths) < 2 || lengths[0] {
+		t.Fatalf("expected several non-zero lengths, got %v", lengths)
+	}
+}
+
+func TestMixSeed(t *testing.T) {
+	if MixSeed("ci", "get", "/items/1") != MixSeed("ci", "GET", "/items/1") {
+		t.Fatalf("expected method case to be ignored")
+	}
+	base := MixSeed("ci", "GET", "/items/1")
+	for _, other := range []uint64{
+		MixSeed("ci", "GET", "/items/2"),
+		MixSeed("ci", "POST", "/items/1"),
+		MixSeed("other", "GET", "/items/1"),
+	} {
+		if other == base {
+			t.Fatalf("expected a different seed")
+		}
+	}
+}
+
+func TestNameWords(t *testing.T) {
+	cases := map[string][]string{
+		"createdAt":   {"created", "at"},
+		"owner_name":  {"owner", "name"},
+		"last-seen":   {"last", "seen"},
+		"IPAddress":   {"ipaddress"},
+		"hostIp":      {"host", "ip"},
+		"description": {"description"},
+	}
+	for in, want := range cases {
+		if got := nameWords(in); !reflect.DeepEqual(got, want) {
+			t.Fatalf("%q: expected %v, got %v", in, want, got)
+		}
+	}
+}
@@ -0,0 +1,428 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openapi
+
+import (
+	"maps"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// schemaGenerator builds sample values from response schemas.
+type schemaGenerator struct {
+	// schemas resolves discriminator mapping targets.
+	schemas openapi3.Schemas
+	opts    ExampleOptions
+	// rnd is set for realistic values; nil keeps fixed placeholders.
+	rnd *rand.Rand
+	// list is the paged list of the response, generated with
+	// opts.ListLength items.
+	list *openapi3.Schema
+}
+
+func (p *SpecProvider) generator(opts ExampleOptions) *schemaGenerator {
+	g := &schemaGenerator{opts: opts}
+	if p.spec != nil && p.spec.Doc3 != nil && p.spec.Doc3.Components != nil {
+		g.schemas = p.spec.Doc3.Components.Schemas
+	}
+	if opts.Random {
+		g.rnd = newRand(opts.Seed)
+	}
+	return g
+}
+
+// defaultMaxDepth is the nesting depth at which generation stops.
+const defaultMaxDepth = 6
+
+func (g *schemaGenerator) genFromSchemaRef(ref *openapi3.SchemaRef, visiting map[string]bool, depth int) any {
+	return g.genSchema(ref, visiting, depth, true)
+}
+
+// genSchema generates a value for ref. visiting holds the $refs being
+// generated; a ref that is reached again, or a depth past the maximum,
+// yields the smallest valid value instead. dispatch lets a base schema with
+// a discriminator mapping generate one of its subtypes.
+func (g *schemaGenerator) genSchema(ref *openapi3.SchemaRef, visiting map[string]bool, depth int, dispatch bool) any {
+	if ref == nil || ref.Value == nil {
+		return map[string]any{}
+	}
+	if g.stops(ref, visiting, depth) {
+		return minimalValue(ref, 0)
+	}
+
+	s := ref.Value
+
+	// the base is replaced by the subtype, so it is not marked as visiting
+	if dispatch {
+		if sub, key := g.subtype(ref); sub != nil {
+			return withDiscriminator(g.genFromSchemaRef(sub, visiting, depth+1), s.Discriminator, key)
+		}
+	}
+
+	if ref.Ref != "" {
+		visiting[ref.Ref] = true
+		defer delete(visiting, ref.Ref)
+	}
+
+	// enum wins
+	if len(s.Enum) > 0 {
+		if g.rnd != nil {
+			return s.Enum[g.rnd.IntN(len(s.Enum))]
+		}
+		return s.Enum[0]
+	}
+
+	// COMPOSITION
+	if len(s.AllOf) > 0 {
+		return g.genAllOf(ref, visiting, depth)
+	}
+	if branches := s.OneOf; len(branches) > 0 {
+		return g.genBranch(s, branches, visiting, depth)
+	}
+	if branches := s.AnyOf; len(branches) > 0 {
+		return g.genBranch(s, branches, visiting, depth)
+	}
+
+	typ := schemaType(s)
+
+	// ARRAY
+	if typ == openapi3.TypeArray {
+		if s.Items == nil || (s.MinItems == 0 && g.stops(s.Items, visiting, depth+1)) {
+			return []any{}
+		}
+		n := g.arrayLen(s)
+		if s == g.list {
+			n = uint64(g.opts.ListLength)
+		}
+		out := make([]any, 0, n)
+		for i := uint64(0); i < n; i++ {
+			out = append(out, g.genFromSchemaRef(s.Items, visiting, depth+1))
+		}
+		return out
+	}
+
+	// OBJECT
+	if typ == openapi3.TypeObject || len(s.Properties) > 0 || s.AdditionalProperties.Schema != nil {
+		return g.genObject(s, visiting, depth)
+	}
+
+	// PRIMITIVES
+	if v, ok := g.genPrimitive(s, ""); ok {
+		return v
+	}
+
+	// fallback
+	return map[string]any{"ok": true}
+}
+
+// genBody generates the body of a response schema ref, marking its list
+// for ListLength.
+func (g *schemaGenerator) genBody(ref *openapi3.SchemaRef) any {
+	g.list = nil
+	if g.opts.ListLength > 0 {
+		if list := listSchema(ref); list != nil {
+			g.list = list.Value
+		}
+	}
+	return g.genFromSchemaRef(ref, map[string]bool{}, 0)
+}
+
+// stops reports whether generation of ref ends here: at a $ref cycle or
+// past the maximum depth.
+func (g *schemaGenerator) stops(ref *openapi3.SchemaRef, visiting map[string]bool, depth int) bool {
+	maxDepth := g.opts.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = defaultMaxDepth
+	}
+	return depth > maxDepth || (ref != nil && ref.Ref != "" && visiting[ref.Ref])
+}
+
+// genPrimitive returns a value for a string, integer, number or boolean
+// schema. Realistic values are derived from the property name, if known.
+func (g *schemaGenerator) genPrimitive(s *openapi3.Schema, name string) (any, bool) {
+	switch typ := schemaType(s); {
+	case typ == openapi3.TypeString && g.rnd != nil:
+		return g.fakeString(s, name), true
+	case typ == openapi3.TypeString:
+		return genString(s), true
+	case typ == openapi3.TypeInteger && g.rnd != nil:
+		return g.fakeInteger(s, name), true
+	case typ == openapi3.TypeInteger:
+		return genInteger(s), true
+	case typ == openapi3.TypeNumber && g.rnd != nil:
+		return g.fakeNumber(s, name), true
+	case typ == openapi3.TypeNumber:
+		return genNumber(s), true
+	case typ == openapi3.TypeBoolean:
+		return g.rnd == nil || g.rnd.IntN(2) == 0, true
+	}
+	return nil, false
+}
+
+// arrayLen returns the number of items to generate: the configured array
+// length (1 by default), or a random length up to it (5 by default) for
+// realistic values, within minItems and maxItems.
+func (g *schemaGenerator) arrayLen(s *openapi3.Schema) uint64 {
+	n := uint64(max(g.opts.ArrayLength, 1))
+	if g.rnd != nil {
+		hi := uint64(5)
+		if g.opts.ArrayLength > 0 {
+			hi = uint64(g.opts.ArrayLength)
+		}
+		n = 1 + g.rnd.Uint64N(hi)
+	}
+	n = max(n, s.MinItems)
+	if s.MaxItems != nil && *s.MaxItems < n {
+		n = *s.MaxItems
+	}
+	return n
+}
+
+func (g *schemaGenerator) genObject(s *openapi3.Schema, visiting map[string]bool, depth int) any {
+	out := map[string]any{}
+
+	// additionalProperties: schema form
+	if s.AdditionalProperties.Schema != nil {
+		out["key"] = g.genFromSchemaRef(s.AdditionalProperties.Schema, visiting, depth+1)
+		return out
+	}
+
+	if s.AdditionalProperties.Has != nil && *s.AdditionalProperties.Has {
+		out["key"] = "value"
+	}
+
+	// properties, in a fixed order so that seeded values are reproducible
+	for _, name := range sortedKeys(s.Properties) {
+		out[name] = g.genProperty(name, s.Properties[name], visiting, depth+1)
+	}
+
+	return out
+}
+
+// genProperty generates the value of the named property; plain primitives
+// get realistic values that fit the name.
+func (g *schemaGenerator) genProperty(name string, ref *openapi3.SchemaRef, visiting map[string]bool, depth int) any {
+	if g.rnd != nil && !g.stops(ref, visiting, depth) && ref != nil && ref.Value != nil && len(ref.Value.Enum) == 0 {
+		if v, ok := g.genPrimitive(ref.Value, name); ok {
+			return v
+		}
+	}
+	return g.genFromSchemaRef(ref, visiting, depth)
+}
+
+// genAllOf merges the values of the allOf members and of the schema's own
+// properties. A member with a discriminator gets its property set to the
+// value that maps to the composed schema.
+func (g *schemaGenerator) genAllOf(ref *openapi3.SchemaRef, visiting map[string]bool, depth int) any {
+	own := *ref.Value
+	own.AllOf = nil
+	parts := append(openapi3.SchemaRefs{}, ref.Value.AllOf...)
+	parts = append(parts, &openapi3.SchemaRef{Value: &own})
+
+	var (
+		merged map[string]any
+		first  any
+	)
+	for _, part := range parts {
+		if part == nil || part.Value == nil || isEmptySchema(part.Value) {
+			continue
+		}
+		// a nullable reference such as {allOf: [$ref], nullable: true}
+		if ref.Value.PermitsNull() && g.stops(part, visiting, depth+1) {
+			return nil
+		}
+
+		// members are generated as themselves, not as one of their subtypes
+		v := g.genSchema(part, visiting, depth+1, false)
+
+		if obj, ok := v.(map[string]any); ok {
+			if merged == nil {
+				merged = map[string]any{}
+			}
+			maps.Copy(merged, obj)
+		} else if first == nil {
+			first = v
+		}
+	}
+
+	if merged == nil {
+		if first != nil {
+			return first
+		}
+		return map[string]any{"ok": true}
+	}
+
+	for _, part := range ref.Value.AllOf {
+		if part != nil && part.Value != nil && part.Value.Discriminator != nil {
+			withDiscriminator(merged, part.Value.Discriminator, discriminatorValue(part.Value.Discriminator, ref.Ref))
+		}
+	}
+	return merged
+}
+
+// genBranch generates the chosen oneOf/anyOf branch and sets the
+// discriminator property of s, if any, to the value mapping to it.
+func (g *schemaGenerator) genBranch(s *openapi3.Schema, branches openapi3.SchemaRefs, visiting map[string]bool, depth int) any {
+	b := branches[g.chooseBranch(branches, s.Discriminator)]
+	v := g.genFromSchemaRef(b, visiting, depth+1)
+	if s.Discriminator != nil && b != nil {
+		v = withDiscriminator(v, s.Discriminator, discriminatorValue(s.Discriminator, b.Ref))
+	}
+	return v
+}
+
+// chooseBranch returns the index of the branch selected by the Branch
+// option: a discriminator mapping key, a schema name or title, or an index.
+// Without a match the first branch is used.
+func (g *schemaGenerator) chooseBranch(branches openapi3.SchemaRefs, d *openapi3.Discriminator) int {
+	want := g.opts.Branch
+	if want == "" {
+		return 0
+	}
+
+	if d != nil {
+		if target, ok := d.Mapping[want]; ok {
+			for i, b := range branches {
+				if b != nil && b.Ref != "" && refName(b.Ref) == refName(target) {
+					return i
+				}
+			}
+		}
+	}
+	for i, b := range branches {
+		if b == nil {
+			continue
+		}
+		if (b.Ref != "" && refName(b.Ref) == want) || (b.Value != nil && b.Value.Title == want) {
+			return i
+		}
+	}
+	if i, err := strconv.Atoi(want); err == nil && i >= 0 && i < len(branches) {
+		return i
+	}
+	return 0
+}
+
+// subtype resolves the schema a discriminator mapping of ref points to, for
+// base schemas referenced directly rather than through oneOf. The Branch
+// option selects the mapping key; otherwise the first key is used.
+func (g *schemaGenerator) subtype(ref *openapi3.SchemaRef) (*openapi3.SchemaRef, string) {
+	s := ref.Value
+	if s.Discriminator == nil || len(s.Discriminator.Mapping) == 0 || ref.Ref == "" || len(s.OneOf) > 0 || len(s.AnyOf) > 0 {
+		return nil, ""
+	}
+	d := s.Discriminator
+
+	key := g.opts.Branch
+	if _, ok := d.Mapping[key]; !ok {
+		key = sortedKeys(d.Mapping)[0]
+	}
+	target := d.Mapping[key]
+	sub := g.schemas[refName(target)]
+	if sub == nil || sub.Value == nil || sub.Value == ref.Value {
+		return nil, ""
+	}
+	return &openapi3.SchemaRef{Ref: target, Value: sub.Value}, key
+}
+
+// minimalValue returns the smallest value valid for ref, used where
+// recursion stops: null for nullable schemas, arrays with minItems entries
+// and objects with only their required properties.
+func minimalValue(ref *openapi3.SchemaRef, level int) any {
+	if ref == nil || ref.Value == nil || level > defaultMaxDepth {
+		return map[string]any{}
+	}
+
+	s := ref.Value
+	switch {
+	case len(s.Enum) > 0:
+		return s.Enum[0]
+	case s.PermitsNull():
+		return nil
+	case len(s.AllOf) > 0:
+		merged := map[string]any{}
+		for _, part := range s.AllOf {
+			if part == nil || part.Value == nil || isEmptySchema(part.Value) {
+				continue
+			}
+			obj, ok := minimalValue(part, level+1).(map[string]any)
+			if !ok {
+				return minimalValue(part, level+1)
+			}
+			maps.Copy(merged, obj)
+		}
+		return merged
+	case len(s.OneOf) > 0:
+		return minimalValue(s.OneOf[0], level+1)
+	case len(s.AnyOf) > 0:
+		return minimalValue(s.AnyOf[0], level+1)
+	}
+
+	switch schemaType(s) {
+	case openapi3.TypeArray:
+		out := []any{}
+		for i := uint64(0); i < s.MinItems; i++ {
+			out = append(out, minimalValue(s.Items, level+1))
+		}
+		return out
+	case openapi3.TypeString:
+		return genString(s)
+	case openapi3.TypeInteger:
+		return genInteger(s)
+	case openapi3.TypeNumber:
+		return genNumber(s)
+	case openapi3.TypeBoolean:
+		return false
+	}
+
+	out := map[string]any{}
+	for _, name := range s.Required {
+		if prop := s.Properties[name]; prop != nil {
+			out[name] = minimalValue(prop, level+1)
+		}
+	}
+	return out
+}
+
+// discriminatorValue returns the mapping key that points to the schema ref,
+// or the schema name when the mapping has none.
+func discriminatorValue(d *openapi3.Discriminator, ref string) string {
+	if ref == "" {
+		return ""
+	}
+	for _, key := range sortedKeys(d.Mapping) {
+		if refName(d.Mapping[key]) == refName(ref) {
+			return key
+		}
+	}
+	return refName(ref)
+}
+
+// withDiscriminator sets the discriminator property of the object v.
+func withDiscriminator(v any, d *openapi3.Discriminator, value string) any {
+	if obj, ok := v.(map[string]any); ok && d.PropertyName != "" && value != "" {
+		obj[d.PropertyName] = value
+	}
+	return v
+}
+
+// refName returns the schema name of a reference such as
+// "#/components/schemas/Cat". Plain names are returned as they are.
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+// isEmptySchema reports whether s places no shape on the value, like the
+// constraint-only members often found in allOf.
+func isEmptySchema(s *openapi3.Schema) bool {
+	return schemaType(s) == "" &&
+		len(s.Properties) == 0 && s.Items == nil && len(s.Enum) == 0 &&
+		len(s.AllOf) == 0 && len(s.OneOf) == 0 && len(s.AnyOf) == 0 &&
+		s.AdditionalProperties.Schema == nil && s.AdditionalProperties.Has == nil &&
+		(s.Discriminator == nil || len(s.Discriminator.Mapping) == 0)
+}
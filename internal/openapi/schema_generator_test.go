@@ -0,0 +1,308 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const petsSpec = `
+openapi: 3.0.3
+info: {title: pets, version: "1"}
+paths:
+  /pets/{id}:
+    get:
+      parameters: [{name: id, in: path, required: true, schema: {type: string}}]
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                oneOf:
+                  - $ref: "#/components/schemas/Cat"
+                  - $ref: "#/components/schemas/Dog"
+                discriminator:
+                  propertyName: petType
+                  mapping:
+                    cat: "#/components/schemas/Cat"
+                    dog: "#/components/schemas/Dog"
+  /cats/{id}:
+    get:
+      parameters: [{name: id, in: path, required: true, schema: {type: string}}]
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema: {$ref: "#/components/schemas/Cat"}
+  /animals/{id}:
+    get:
+      parameters: [{name: id, in: path, required: true, schema: {type: string}}]
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema: {$ref: "#/components/schemas/Pet"}
+  /results:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                anyOf:
+                  - {type: object, title: scan, required: [scanId], properties: {scanId: {type: string, format: uuid}}}
+                  - {type: object, title: task, required: [taskId], properties: {taskId: {type: integer, minimum: 1}}}
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [petType, name]
+      properties:
+        petType: {type: string}
+        name: {type: string, minLength: 2}
+      discriminator:
+        propertyName: petType
+        mapping:
+          cat: "#/components/schemas/Cat"
+          dog: "#/components/schemas/Dog"
+    Cat:
+      allOf:
+        - $ref: "#/components/schemas/Pet"
+        - type: object
+          required: [meows]
+          properties:
+            meows: {type: boolean}
+        - required: [name]
+    Dog:
+      allOf:
+        - $ref: "#/components/schemas/Pet"
+        - type: object
+          required: [barks]
+          properties:
+            barks: {type: integer, minimum: 3}
+`
+
+// petExample generates the example of GET path for branch and checks that
+// it validates against the response schema.
+func petExample(t *testing.T, p ISpecProvider, path, branch string) map[string]any {
+	t.Helper()
+
+	ex, ok := p.TryGetExample(path, "GET", ExampleOptions{Branch: branch})
+	if !ok {
+		t.Fatalf("%s: expected example", path)
+	}
+	var v any
+	if err := json.Unmarshal(ex.Body, &v); err != nil {
+		t.Fatalf("decode %s: %v", ex.Body, err)
+	}
+
+	sch := p.FindOperation(path, "GET").Responses.Value("200").Value.Content["application/json"].Schema.Value
+	if err := sch.VisitJSON(v, openapi3.EnableFormatValidation()); err != nil {
+		t.Fatalf("%s: generated %s does not validate: %v", path, ex.Body, err)
+	}
+	m, _ := v.(map[string]any)
+	return m
+}
+
+func TestGenerate_AllOfMergesMembersAndSetsDiscriminator(t *testing.T) {
+	p := newTestProvider(t, petsSpec)
+
+	m := petExample(t, p, "/cats/{id}", "")
+	if m["petType"] != "cat" || m["meows"] != true || m["name"] == nil {
+		t.Fatalf("unexpected cat: %#v", m)
+	}
+}
+
+func TestGenerate_OneOfBranchSelection(t *testing.T) {
+	p := newTestProvider(t, petsSpec)
+
+	cases := map[string]string{
+		"":      "cat",
+		"dog":   "dog",
+		"Dog":   "dog",
+		"1":     "dog",
+		"bogus": "cat",
+	}
+	for branch, want := range cases {
+		m := petExample(t, p, "/pets/{id}", branch)
+		if m["petType"] != want {
+			t.Fatalf("branch %q: expected petType %q, got %#v", branch, want, m)
+		}
+		if _, barks := m["barks"]; barks != (want == "dog") {
+			t.Fatalf("branch %q: unexpected body %#v", branch, m)
+		}
+	}
+}
+
+func TestGenerate_DiscriminatorBaseUsesMappedSubtype(t *testing.T) {
+	p := newTestProvider(t, petsSpec)
+
+	if m := petExample(t, p, "/animals/{id}", ""); m["petType"] != "cat" || m["meows"] != true {
+		t.Fatalf("expected first mapped subtype, got %#v", m)
+	}
+	if m := petExample(t, p, "/animals/{id}", "dog"); m["petType"] != "dog" || m["barks"] == nil {
+		t.Fatalf("expected selected subtype, got %#v", m)
+	}
+}
+
+func TestGenerate_AnyOfByTitle(t *testing.T) {
+	p := newTestProvider(t, petsSpec)
+
+	if m := petExample(t, p, "/results", ""); m["scanId"] == nil {
+		t.Fatalf("expected first branch, got %#v", m)
+	}
+	if m := petExample(t, p, "/results", "task"); m["taskId"] != float64(1) {
+		t.Fatalf("expected task branch, got %#v", m)
+	}
+}
+
+func TestGenerate_AllOfScalarMembers(t *testing.T) {
+	g := &schemaGenerator{}
+
+	got := g.genFromSchemaRef(&openapi3.SchemaRef{Value: &openapi3.Schema{
+		AllOf: openapi3.SchemaRefs{
+			{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Format: "uuid"}},
+			{Value: &openapi3.Schema{MaxLength: uint64p(40)}},
+		},
+	}}, map[string]bool{}, 0)
+
+	if got != formatValues["uuid"] {
+		t.Fatalf("expected uuid string, got %#v", got)
+	}
+}
+
+const treeSpec = `
+openapi: 3.0.3
+info: {title: tree, version: "1"}
+paths:
+  /folders/{id}:
+    get:
+      parameters: [{name: id, in: path, required: true, schema: {type: string}}]
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema: {$ref: "#/components/schemas/Folder"}
+components:
+  schemas:
+    Folder:
+      type: object
+      required: [name, children]
+      properties:
+        name: {type: string, minLength: 1}
+        children:
+          type: array
+          items: {$ref: "#/components/schemas/Folder"}
+        parent: {$ref: "#/components/schemas/Folder"}
+        link:
+          nullable: true
+          allOf: [{$ref: "#/components/schemas/Folder"}]
+        findings:
+          type: array
+          minItems: 1
+          items: {$ref: "#/components/schemas/Finding"}
+    Finding:
+      type: object
+      required: [id, related]
+      properties:
+        id: {type: integer, minimum: 1}
+        related:
+          type: array
+          items: {$ref: "#/components/schemas/Finding"}
+        folder: {$ref: "#/components/schemas/Folder"}
+`
+
+func TestGenerate_RecursiveSchemaStopsAtCycles(t *testing.T) {
+	p := newTestProvider(t, treeSpec)
+
+	m := petExample(t, p, "/folders/{id}", "")
+	b, _ := json.Marshal(m)
+
+	if children, _ := m["children"].([]any); children == nil || len(children) != 0 {
+		t.Fatalf("expected recursive children to stop as [], got %s", b)
+	}
+	if m["link"] != nil {
+		t.Fatalf("expected nullable recursive link to be null, got %s", b)
+	}
+	parent, _ := m["parent"].(map[string]any)
+	if len(parent) != 2 || parent["name"] == nil {
+		t.Fatalf("expected minimal parent with required properties only, got %s", b)
+	}
+	findings, _ := m["findings"].([]any)
+	finding, _ := findings[0].(map[string]any)
+	if folder, _ := finding["folder"].(map[string]any); folder == nil || folder["findings"] != nil {
+		t.Fatalf("expected folder inside finding to stop, got %s", b)
+	}
+}
+
+func TestGenerate_MaxDepthAndArrayLength(t *testing.T) {
+	leaf := &openapi3.Schema{Type: &openapi3.Types{"object"}, Properties: openapi3.Schemas{
+		"list": {Value: &openapi3.Schema{
+			Type:  &openapi3.Types{"array"},
+			Items: &openapi3.SchemaRef{Value: openapi3.NewIntegerSchema()},
+		}},
+		"pair": {Value: &openapi3.Schema{
+			Type:     &openapi3.Types{"array"},
+			MaxItems: uint64p(2),
+			Items:    &openapi3.SchemaRef{Value: openapi3.NewIntegerSchema()},
+		}},
+	}}
+	nested := &openapi3.Schema{Type: &openapi3.Types{"object"}, Properties: openapi3.Schemas{
+		"a": {Value: &openapi3.Schema{Type: &openapi3.Types{"object"}, Properties: openapi3.Schemas{
+			"b": {Value: leaf},
+		}}},
+	}}
+
+	g := &schemaGenerator{opts: ExampleOptions{MaxDepth: 1, ArrayLength: 3}}
+	got := g.genFromSchemaRef(&openapi3.SchemaRef{Value: nested}, map[string]bool{}, 0)
+	if b := got.(map[string]any)["a"].(map[string]any)["b"]; len(b.(map[string]any)) != 0 {
+		t.Fatalf("expected generation to stop past MaxDepth, got %#v", b)
+	}
+
+	g = &schemaGenerator{opts: ExampleOptions{ArrayLength: 3}}
+	got = g.genFromSchemaRef(&openapi3.SchemaRef{Value: leaf}, map[string]bool{}, 0)
+	m := got.(map[string]any)
+	if len(m["list"].([]any)) != 3 || len(m["pair"].([]any)) != 2 {
+		t.Fatalf("expected ArrayLength within maxItems, got %#v", m)
+	}
+}
+
+func TestMinimalValue(t *testing.T) {
+	cases := []struct {
+		name string
+		s    *openapi3.Schema
+		want string
+	}{
+		{"nullable", &openapi3.Schema{Type: &openapi3.Types{"object"}, Nullable: true}, `null`},
+		{"required only", &openapi3.Schema{
+			Type:     &openapi3.Types{"object"},
+			Required: []string{"id"},
+			Properties: openapi3.Schemas{
+				"id":   {Value: &openapi3.Schema{Type: &openapi3.Types{"integer"}, Min: float(3)}},
+				"name": {Value: openapi3.NewStringSchema()},
+			},
+		}, `{"id":3}`},
+		{"minItems", &openapi3.Schema{
+			Type:     &openapi3.Types{"array"},
+			MinItems: 2,
+			Items:    &openapi3.SchemaRef{Value: openapi3.NewBoolSchema()},
+		}, `[false,false]`},
+		{"oneOf", &openapi3.Schema{OneOf: openapi3.SchemaRefs{{Value: openapi3.NewStringSchema()}}}, `"string"`},
+	}
+	for _, tc := range cases {
+		b, _ := json.Marshal(minimalValue(&openapi3.SchemaRef{Value: tc.s}, 0))
+		if string(b) != tc.want {
+			t.Fatalf("%s: expected %s, got %s", tc.name, tc.want, b)
+		}
+	}
+}
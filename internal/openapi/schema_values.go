@@ -0,0 +1,257 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openapi
+
+import (
+	"math"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// formatValues are valid sample values for the common string formats.
+var formatValues = map[string]string{
+	"date-time":     "2026-01-28T00:00:00Z",
+	"date":          "2026-01-28",
+	"time":          "00:00:00",
+	"duration":      "PT1H",
+	"uuid":          "3fa85f64-5717-4562-b3fc-2c963f66afa6",
+	"email":         "user@example.com",
+	"idn-email":     "user@example.com",
+	"uri":           "https://example.com",
+	"url":           "https://example.com",
+	"iri":           "https://example.com",
+	"uri-reference": "/example",
+	"iri-reference": "/example",
+	"uri-template":  "https://example.com/{id}",
+	"hostname":      "example.com",
+	"idn-hostname":  "example.com",
+	"ipv4":          "192.0.2.1",
+	"ipv6":          "2001:db8::1",
+	"byte":          "c3RyaW5n",
+	"json-pointer":  "/example",
+	"regex":         "^example$",
+}
+
+// schemaType returns the type of s, skipping "null" in OpenAPI 3.1 type
+// lists, or "" when s has no type.
+func schemaType(s *openapi3.Schema) string {
+	if s.Type == nil {
+		return ""
+	}
+	for _, t := range *s.Type {
+		if t != openapi3.TypeNull {
+			return t
+		}
+	}
+	return ""
+}
+
+// genString returns a string that fits the pattern, format and length
+// constraints of s.
+func genString(s *openapi3.Schema) string {
+	if s.Pattern != "" {
+		if v, ok := patternString(s.Pattern, s.MinLength, s.MaxLength); ok {
+			return v
+		}
+	}
+	if v, ok := formatValues[s.Format]; ok {
+		return v
+	}
+	return fitLength("string", s.MinLength, s.MaxLength)
+}
+
+// fitLength pads or truncates v to the length bounds, counted in runes.
+func fitLength(v string, minLen uint64, maxLen *uint64) string {
+	if n := uint64(utf8.RuneCountInString(v)); n < minLen {
+		v += strings.Repeat("x", int(minLen-n))
+	}
+	if maxLen != nil && uint64(utf8.RuneCountInString(v)) > *maxLen {
+		v = string([]rune(v)[:*maxLen])
+	}
+	return v
+}
+
+// patternString builds a string matching pattern within the length bounds.
+// Unbounded repetitions are grown until the minimum length is reached.
+func patternString(pattern string, minLen uint64, maxLen *uint64) (string, bool) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", false
+	}
+	check, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", false
+	}
+
+	for extra := 0; extra <= 64; extra++ {
+		var sb strings.Builder
+		writePattern(&sb, re.Simplify(), extra)
+		v := sb.String()
+
+		n := uint64(utf8.RuneCountInString(v))
+		if maxLen != nil && n > *maxLen {
+			return "", false
+		}
+		if n >= minLen && check.MatchString(v) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// writePattern writes the shortest match of re, with extra additional
+// repetitions for every unbounded repeat.
+func writePattern(sb *strings.Builder, re *syntax.Regexp, extra int) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			sb.WriteRune(r)
+		}
+	case syntax.OpCharClass:
+		sb.WriteRune(classRune(re.Rune))
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		sb.WriteRune('a')
+	case syntax.OpCapture:
+		writePattern(sb, re.Sub[0], extra)
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			writePattern(sb, sub, extra)
+		}
+	case syntax.OpAlternate:
+		writePattern(sb, re.Sub[0], extra)
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		lo, hi := repeatBounds(re)
+		n := lo + extra
+		if hi >= 0 && n > hi {
+			n = hi
+		}
+		for i := 0; i < n; i++ {
+			writePattern(sb, re.Sub[0], extra)
+		}
+	}
+}
+
+func repeatBounds(re *syntax.Regexp) (int, int) {
+	switch re.Op {
+	case syntax.OpStar:
+		return 0, -1
+	case syntax.OpPlus:
+		return 1, -1
+	case syntax.OpQuest:
+		return 0, 1
+	default:
+		return re.Min, re.Max
+	}
+}
+
+// classRune picks a readable rune from a character class given as ranges,
+// preferring lowercase letters, then digits.
+func classRune(ranges []rune) rune {
+	for _, want := range []rune{'a', 'A', '0'} {
+		for i := 0; i+1 < len(ranges); i += 2 {
+			if ranges[i] <= want && want <= ranges[i+1] {
+				return want
+			}
+		}
+	}
+	for i := 0; i+1 < len(ranges); i += 2 {
+		if ranges[i] >= ' ' {
+			return ranges[i]
+		}
+	}
+	if len(ranges) > 0 {
+		return ranges[0]
+	}
+	return 'a'
+}
+
+// genInteger returns the integer closest to 0 within the bounds of s that
+// is a multiple of multipleOf.
+func genInteger(s *openapi3.Schema) int {
+	lo, hi, step := intBounds(s)
+	return int(pickInRange(lo, hi, step))
+}
+
+// intBounds returns the inclusive integer bounds of s and the step of its
+// integer multipleOf, or 1.
+func intBounds(s *openapi3.Schema) (float64, float64, float64) {
+	lo, hi := math.Inf(-1), math.Inf(1)
+	if s.Min != nil {
+		lo = math.Ceil(*s.Min)
+		if s.ExclusiveMin && lo == *s.Min {
+			lo++
+		}
+	}
+	if s.Max != nil {
+		hi = math.Floor(*s.Max)
+		if s.ExclusiveMax && hi == *s.Max {
+			hi--
+		}
+	}
+
+	step := 1.0
+	if s.MultipleOf != nil && *s.MultipleOf > 0 && *s.MultipleOf == math.Trunc(*s.MultipleOf) {
+		step = *s.MultipleOf
+	}
+	return lo, hi, step
+}
+
+// genNumber returns the number closest to 0 within the bounds of s that is
+// a multiple of multipleOf.
+func genNumber(s *openapi3.Schema) float64 {
+	lo, hi := math.Inf(-1), math.Inf(1)
+	if s.Min != nil {
+		lo = *s.Min
+	}
+	if s.Max != nil {
+		hi = *s.Max
+	}
+
+	if s.MultipleOf != nil && *s.MultipleOf > 0 {
+		step := *s.MultipleOf
+		if s.ExclusiveMin && s.Min != nil && math.Mod(lo, step) == 0 {
+			lo += step
+		}
+		if s.ExclusiveMax && s.Max != nil && math.Mod(hi, step) == 0 {
+			hi -= step
+		}
+		return pickInRange(lo, hi, step)
+	}
+
+	v := math.Max(lo, math.Min(0, hi))
+	excludedLo := s.ExclusiveMin && s.Min != nil && v == lo
+	excludedHi := s.ExclusiveMax && s.Max != nil && v == hi
+	switch {
+	case !excludedLo && !excludedHi:
+		return v
+	case s.Min != nil && s.Max != nil:
+		return lo + (hi-lo)/2
+	case excludedLo:
+		return lo + 1
+	default:
+		return hi - 1
+	}
+}
+
+// pickInRange returns the multiple of step in [lo, hi] closest to 0, or lo
+// when there is none.
+func pickInRange(lo, hi, step float64) float64 {
+	v := math.Max(lo, math.Min(0, hi))
+	c := math.Ceil(v/step) * step
+	if c > hi {
+		c = math.Floor(v/step) * step
+	}
+	if c < lo || c > hi {
+		return lo
+	}
+	if c == 0 {
+		return 0 // no negative zero
+	}
+	return c
+}
@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openapi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net"
+	"net/mail"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func float(v float64) *float64 { return &v }
+
+func uint64p(v uint64) *uint64 { return &v }
+
+// generated runs the generator for sch and returns the value as decoded
+// from its JSON encoding, the way a client sees it.
+func generated(t *testing.T, sch *openapi3.Schema) any {
+	t.Helper()
+
+	g := &schemaGenerator{}
+	b, err := json.Marshal(g.genFromSchemaRef(&openapi3.SchemaRef{Value: sch}, map[string]bool{}, 0))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var v any
+	if err := json.Unmarshal(b, &v); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	return v
+}
+
+func TestGenFromSchemaRef_ValuesValidateAgainstConstraints(t *testing.T) {
+	str := func(mod func(s *openapi3.Schema)) *openapi3.Schema {
+		s := openapi3.NewStringSchema()
+		mod(s)
+		return s
+	}
+	num := func(typ string, mod func(s *openapi3.Schema)) *openapi3.Schema {
+		s := &openapi3.Schema{Type: &openapi3.Types{typ}}
+		mod(s)
+		return s
+	}
+
+	cases := map[string]*openapi3.Schema{
+		"minLength":            str(func(s *openapi3.Schema) { s.MinLength = 12 }),
+		"maxLength":            str(func(s *openapi3.Schema) { s.MaxLength = uint64p(3) }),
+		"pattern":              str(func(s *openapi3.Schema) { s.Pattern = `^[A-Z]{3}-\d{4}$` }),
+		"pattern alternation":  str(func(s *openapi3.Schema) { s.Pattern = `^(scan|task)_[a-f0-9]+$` }),
+		"pattern minLength":    str(func(s *openapi3.Schema) { s.Pattern = `^[a-z]+$`; s.MinLength = 8 }),
+		"pattern unanchored":   str(func(s *openapi3.Schema) { s.Pattern = `v\d+\.\d+` }),
+		"integer minimum":      num("integer", func(s *openapi3.Schema) { s.Min = float(5) }),
+		"integer maximum":      num("integer", func(s *openapi3.Schema) { s.Max = float(-3) }),
+		"integer exclusive":    num("integer", func(s *openapi3.Schema) { s.Min = float(0); s.ExclusiveMin = true }),
+		"integer multipleOf":   num("integer", func(s *openapi3.Schema) { s.Min = float(7); s.MultipleOf = float(5) }),
+		"integer range":        num("integer", func(s *openapi3.Schema) { s.Min = float(10); s.Max = float(20) }),
+		"number exclusive":     num("number", func(s *openapi3.Schema) { s.Min = float(0); s.ExclusiveMin = true }),
+		"number exclusive max": num("number", func(s *openapi3.Schema) { s.Max = float(0); s.ExclusiveMax = true }),
+		"number bounded excl": num("number", func(s *openapi3.Schema) {
+			s.Min, s.Max, s.ExclusiveMin = float(0), float(1), true
+		}),
+		"number multipleOf": num("number", func(s *openapi3.Schema) { s.Min = float(0.3); s.MultipleOf = float(0.25) }),
+		"array minItems": {
+			Type:     &openapi3.Types{"array"},
+			MinItems: 3,
+			Items:    &openapi3.SchemaRef{Value: openapi3.NewIntegerSchema()},
+		},
+		"array maxItems zero": {
+			Type:     &openapi3.Types{"array"},
+			MaxItems: uint64p(0),
+			Items:    &openapi3.SchemaRef{Value: openapi3.NewIntegerSchema()},
+		},
+		"nullable type list": {Type: &openapi3.Types{"null", "integer"}, Min: float(2)},
+	}
+
+	for name, sch := range cases {
+		t.Run(name, func(t *testing.T) {
+			v := generated(t, sch)
+			if err := sch.VisitJSON(v, openapi3.EnableFormatValidation()); err != nil {
+				t.Fatalf("generated %#v does not validate: %v", v, err)
+			}
+		})
+	}
+}
+
+func TestGenFromSchemaRef_Formats(t *testing.T) {
+	checks := map[string]func(string) bool{
+		"date-time": func(v string) bool { _, err := time.Parse(time.RFC3339, v); return err == nil },
+		"date":      func(v string) bool { _, err := time.Parse(time.DateOnly, v); return err == nil },
+		"uuid":      uuidPattern.MatchString,
+		"email":     func(v string) bool { _, err := mail.ParseAddress(v); return err == nil },
+		"uri":       func(v string) bool { u, err := url.Parse(v); return err == nil && u.IsAbs() },
+		"ipv4":      func(v string) bool { ip := net.ParseIP(v); return ip != nil && ip.To4() != nil },
+		"ipv6":      func(v string) bool { ip := net.ParseIP(v); return ip != nil && ip.To4() == nil },
+		"byte":      func(v string) bool { _, err := base64.StdEncoding.DecodeString(v); return err == nil },
+		"hostname":  func(v string) bool { return v != "" && net.ParseIP(v) == nil },
+	}
+
+	for format, ok := range checks {
+		sch := openapi3.NewStringSchema()
+		sch.Format = format
+		v, _ := generated(t, sch).(string)
+		if !ok(v) {
+			t.Fatalf("%s: invalid value %q", format, v)
+		}
+	}
+}
+
+func TestPatternString_Unsatisfiable(t *testing.T) {
+	if v, ok := patternString(`^[a-z]{10}$`, 0, uint64p(5)); ok {
+		t.Fatalf("expected no value within maxLength, got %q", v)
+	}
+	if _, ok := patternString(`(`, 0, nil); ok {
+		t.Fatalf("expected invalid pattern to fail")
+	}
+
+	sch := openapi3.NewStringSchema()
+	sch.Pattern = `(`
+	if v := generated(t, sch); v != "string" {
+		t.Fatalf("expected plain fallback for invalid pattern, got %#v", v)
+	}
+}
@@ -0,0 +1,260 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// securityRealm is the realm announced in WWW-Authenticate challenges.
+const securityRealm = "openapi-emulator"
+
+// SecurityFailure describes why a request does not satisfy the security
+// requirements of its operation.
+type SecurityFailure struct {
+	// Status is 401 for missing or unknown credentials and 403 for valid
+	// credentials that lack a required scope.
+	Status int
+	// Scheme is the name of the security scheme that rejected the request.
+	Scheme string
+	Reason string
+	// Challenges are the WWW-Authenticate values to send back.
+	Challenges []string
+}
+
+func (f *SecurityFailure) Error() string {
+	return fmt.Sprintf("security scheme %s: %s", f.Scheme, f.Reason)
+}
+
+// CheckSecurity checks r against the security requirements of the matched
+// operation, falling back to the top-level requirements of the spec.
+// Requirements are alternatives; all schemes of one requirement must pass.
+//
+// credentials lists the accepted credentials per scheme name: the API key,
+// "user:password" for HTTP basic, or the token for bearer, OAuth2 and
+// OpenID Connect. A token may carry its granted scopes as
+// "token(scope1 scope2)"; without them it grants every scope. A scheme
+// without credentials accepts any credential that is present.
+func (v *Validator) CheckSecurity(r *http.Request, match *RouteMatch, credentials map[string][]string) *SecurityFailure {
+	spec := v.spec.GetSpec()
+	op := v.spec.FindOperation(match.Route.Swagger, match.Route.Method)
+	if spec == nil || spec.Doc3 == nil || op == nil {
+		return nil
+	}
+
+	reqs := spec.Doc3.Security
+	if op.Security != nil {
+		reqs = *op.Security
+	}
+	if len(reqs) == 0 {
+		return nil
+	}
+
+	var schemes openapi3.SecuritySchemes
+	if spec.Doc3.Components != nil {
+		schemes = spec.Doc3.Components.SecuritySchemes
+	}
+
+	var first, forbidden *SecurityFailure
+	var challenges []string
+	for _, req := range reqs {
+		f := checkRequirement(r, req, schemes, credentials)
+		if f == nil {
+			return nil
+		}
+		if first == nil {
+			first = f
+		}
+		if f.Status == http.StatusForbidden && forbidden == nil {
+			forbidden = f
+		}
+		for _, c := range f.Challenges {
+			if !slices.Contains(challenges, c) {
+				challenges = append(challenges, c)
+			}
+		}
+	}
+
+	// a valid credential without the scope beats a missing one
+	if forbidden != nil {
+		return forbidden
+	}
+	first.Challenges = challenges
+	return first
+}
+
+// checkRequirement checks every scheme of one requirement. Missing or
+// invalid credentials win over missing scopes; their challenges are merged.
+func checkRequirement(r *http.Request, req openapi3.SecurityRequirement, schemes openapi3.SecuritySchemes, credentials map[string][]string) *SecurityFailure {
+	var unauthorized, forbidden *SecurityFailure
+	for _, name := range sortedKeys(req) {
+		ref := schemes[name]
+		if ref == nil || ref.Value == nil {
+			// an undefined scheme is reported by spec validation
+			continue
+		}
+
+		f := checkScheme(r, name, ref.Value, req[name], credentials[name])
+		switch {
+		case f == nil:
+		case f.Status == http.StatusForbidden:
+			if forbidden == nil {
+				forbidden = f
+			}
+		case unauthorized == nil:
+			unauthorized = f
+		default:
+			unauthorized.Challenges = append(unauthorized.Challenges, f.Challenges...)
+		}
+	}
+
+	if unauthorized != nil {
+		return unauthorized
+	}
+	return forbidden
+}
+
+func checkScheme(r *http.Request, name string, sch *openapi3.SecurityScheme, scopes, accepted []string) *SecurityFailure {
+	fail := func(status int, reason string, challenge string) *SecurityFailure {
+		return &SecurityFailure{Status: status, Scheme: name, Reason: reason, Challenges: []string{challenge}}
+	}
+
+	switch strings.ToLower(sch.Type) {
+	case "apikey":
+		challenge := fmt.Sprintf(`ApiKey realm=%q, name=%q, in=%q`, securityRealm, sch.Name, sch.In)
+		key, ok := apiKeyValue(r, sch.In, sch.Name)
+		if !ok {
+			return fail(http.StatusUnauthorized, fmt.Sprintf("missing API key %s in %s", sch.Name, sch.In), challenge)
+		}
+		if len(accepted) > 0 && !slices.Contains(accepted, key) {
+			return fail(http.StatusUnauthorized, "invalid API key", challenge)
+		}
+		return nil
+
+	case "http":
+		switch strings.ToLower(sch.Scheme) {
+		case "basic":
+			challenge := fmt.Sprintf(`Basic realm=%q`, securityRealm)
+			user, pass, ok := r.BasicAuth()
+			if !ok {
+				return fail(http.StatusUnauthorized, "missing basic credentials", challenge)
+			}
+			if len(accepted) > 0 && !slices.Contains(accepted, user+":"+pass) {
+				return fail(http.StatusUnauthorized, "invalid basic credentials", challenge)
+			}
+			return nil
+		case "bearer":
+			return checkBearer(r, name, scopes, accepted)
+		default:
+			// other HTTP schemes are only checked for presence
+			scheme := sch.Scheme
+			if _, ok := authorization(r, scheme); !ok {
+				return fail(http.StatusUnauthorized, "missing "+scheme+" credentials",
+					fmt.Sprintf(`%s realm=%q`, scheme, securityRealm))
+			}
+			return nil
+		}
+
+	case "oauth2", "openidconnect":
+		return checkBearer(r, name, scopes, accepted)
+	}
+
+	// mutualTLS is terminated before the emulator
+	return nil
+}
+
+// checkBearer checks a bearer token and, for OAuth2 and OpenID Connect,
+// the scopes granted to it.
+func checkBearer(r *http.Request, name string, scopes, accepted []string) *SecurityFailure {
+	token, ok := authorization(r, "Bearer")
+	if !ok || token == "" {
+		return &SecurityFailure{
+			Status:     http.StatusUnauthorized,
+			Scheme:     name,
+			Reason:     "missing bearer token",
+			Challenges: []string{fmt.Sprintf(`Bearer realm=%q`, securityRealm)},
+		}
+	}
+	if len(accepted) == 0 {
+		return nil
+	}
+
+	granted, ok := tokenScopes(token, accepted)
+	if !ok {
+		return &SecurityFailure{
+			Status:     http.StatusUnauthorized,
+			Scheme:     name,
+			Reason:     "invalid bearer token",
+			Challenges: []string{fmt.Sprintf(`Bearer realm=%q, error="invalid_token"`, securityRealm)},
+		}
+	}
+	if granted == nil {
+		return nil
+	}
+
+	var missing []string
+	for _, sc := range scopes {
+		if !slices.Contains(granted, sc) {
+			missing = append(missing, sc)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return &SecurityFailure{
+		Status: http.StatusForbidden,
+		Scheme: name,
+		Reason: "token lacks scope " + strings.Join(missing, ", "),
+		Challenges: []string{fmt.Sprintf(`Bearer realm=%q, error="insufficient_scope", scope=%q`,
+			securityRealm, strings.Join(scopes, " "))},
+	}
+}
+
+// tokenScopes looks token up in accepted and returns its scopes. A nil
+// slice means the token grants every scope.
+func tokenScopes(token string, accepted []string) ([]string, bool) {
+	for _, a := range accepted {
+		tok, rest, hasScopes := strings.Cut(a, "(")
+		if strings.TrimSpace(tok) != token {
+			continue
+		}
+		if !hasScopes {
+			return nil, true
+		}
+		return strings.Fields(strings.TrimSuffix(rest, ")")), true
+	}
+	return nil, false
+}
+
+// authorization returns the credentials of the Authorization header when it
+// uses the given scheme.
+func authorization(r *http.Request, scheme string) (string, bool) {
+	h := r.Header.Get("Authorization")
+	prefix, cred, _ := strings.Cut(h, " ")
+	if h == "" || !strings.EqualFold(prefix, scheme) {
+		return "", false
+	}
+	return strings.TrimSpace(cred), true
+}
+
+func apiKeyValue(r *http.Request, in, name string) (string, bool) {
+	var v string
+	switch in {
+	case openapi3.ParameterInHeader:
+		v = r.Header.Get(name)
+	case openapi3.ParameterInQuery:
+		v = r.URL.Query().Get(name)
+	case openapi3.ParameterInCookie:
+		if c, err := r.Cookie(name); err == nil {
+			v = c.Value
+		}
+	}
+	return v, v != ""
+}
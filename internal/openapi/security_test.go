@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openapi
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const securitySpec = `{
+  "openapi":"3.0.3",
+  "info":{"title":"t","version":"1"},
+  "security":[{"bearer":[]}],
+  "components":{"securitySchemes":{
+    "apiKey":{"type":"apiKey","name":"X-API-Key","in":"header"},
+    "queryKey":{"type":"apiKey","name":"key","in":"query"},
+    "cookieKey":{"type":"apiKey","name":"sid","in":"cookie"},
+    "basic":{"type":"http","scheme":"basic"},
+    "bearer":{"type":"http","scheme":"bearer"},
+    "oauth":{"type":"oauth2","flows":{"clientCredentials":{
+      "tokenUrl":"https://example.com/token",
+      "scopes":{"read":"read","write":"write"}
+    }}}
+  }},
+  "paths":{
+    "/default":{"get":{"responses":{"200":{"description":"ok"}}}},
+    "/public":{"get":{"security":[],"responses":{"200":{"description":"ok"}}}},
+    "/either":{"get":{"security":[{"apiKey":[]},{"basic":[]}],"responses":{"200":{"description":"ok"}}}},
+    "/both":{"get":{"security":[{"apiKey":[],"queryKey":[]}],"responses":{"200":{"description":"ok"}}}},
+    "/cookie":{"get":{"security":[{"cookieKey":[]}],"responses":{"200":{"description":"ok"}}}},
+    "/scoped":{"get":{"security":[{"oauth":["read","write"]}],"responses":{"200":{"description":"ok"}}}}
+  }
+}`
+
+func TestCheckSecurity_NoRequirement(t *testing.T) {
+	v, router := newTestValidator(t, securitySpec)
+
+	r, m := routedRequest(t, router, http.MethodGet, "/public", "", nil)
+	if f := v.CheckSecurity(r, m, nil); f != nil {
+		t.Fatalf("expected pass, got %v", f)
+	}
+}
+
+func TestCheckSecurity_TopLevelBearer(t *testing.T) {
+	v, router := newTestValidator(t, securitySpec)
+
+	r, m := routedRequest(t, router, http.MethodGet, "/default", "", nil)
+	f := v.CheckSecurity(r, m, nil)
+	if f == nil || f.Status != http.StatusUnauthorized || f.Scheme != "bearer" {
+		t.Fatalf("expected 401 for bearer, got %#v", f)
+	}
+	if len(f.Challenges) != 1 || f.Challenges[0] != `Bearer realm="openapi-emulator"` {
+		t.Fatalf("unexpected challenges: %v", f.Challenges)
+	}
+
+	// any token passes without configured credentials
+	r, m = routedRequest(t, router, http.MethodGet, "/default", "", map[string]string{"Authorization": "Bearer anything"})
+	if f := v.CheckSecurity(r, m, nil); f != nil {
+		t.Fatalf("expected pass, got %v", f)
+	}
+
+	creds := map[string][]string{"bearer": {"t1"}}
+	if f := v.CheckSecurity(r, m, creds); f == nil || !strings.Contains(f.Challenges[0], `error="invalid_token"`) {
+		t.Fatalf("expected invalid_token, got %#v", f)
+	}
+
+	r, m = routedRequest(t, router, http.MethodGet, "/default", "", map[string]string{"Authorization": "bearer t1"})
+	if f := v.CheckSecurity(r, m, creds); f != nil {
+		t.Fatalf("expected pass, got %v", f)
+	}
+}
+
+func TestCheckSecurity_Alternatives(t *testing.T) {
+	v, router := newTestValidator(t, securitySpec)
+	creds := map[string][]string{"apiKey": {"k1"}, "basic": {"admin:secret"}}
+
+	r, m := routedRequest(t, router, http.MethodGet, "/either", "", nil)
+	f := v.CheckSecurity(r, m, creds)
+	if f == nil || f.Status != http.StatusUnauthorized || f.Scheme != "apiKey" {
+		t.Fatalf("expected 401 for apiKey, got %#v", f)
+	}
+	want := []string{
+		`ApiKey realm="openapi-emulator", name="X-API-Key", in="header"`,
+		`Basic realm="openapi-emulator"`,
+	}
+	if strings.Join(f.Challenges, "|") != strings.Join(want, "|") {
+		t.Fatalf("unexpected challenges: %v", f.Challenges)
+	}
+
+	r, m = routedRequest(t, router, http.MethodGet, "/either", "", map[string]string{"X-API-Key": "k1"})
+	if f := v.CheckSecurity(r, m, creds); f != nil {
+		t.Fatalf("expected api key to pass, got %v", f)
+	}
+
+	r, m = routedRequest(t, router, http.MethodGet, "/either", "", nil)
+	r.SetBasicAuth("admin", "secret")
+	if f := v.CheckSecurity(r, m, creds); f != nil {
+		t.Fatalf("expected basic to pass, got %v", f)
+	}
+
+	r.SetBasicAuth("admin", "wrong")
+	if f := v.CheckSecurity(r, m, creds); f == nil || f.Status != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %#v", f)
+	}
+}
+
+func TestCheckSecurity_AllSchemesOfRequirement(t *testing.T) {
+	v, router := newTestValidator(t, securitySpec)
+
+	r, m := routedRequest(t, router, http.MethodGet, "/both", "", map[string]string{"X-API-Key": "k"})
+	f := v.CheckSecurity(r, m, nil)
+	if f == nil || f.Scheme != "queryKey" || !strings.Contains(f.Reason, "missing API key key in query") {
+		t.Fatalf("expected missing query key, got %#v", f)
+	}
+
+	r, m = routedRequest(t, router, http.MethodGet, "/both?key=q", "", map[string]string{"X-API-Key": "k"})
+	if f := v.CheckSecurity(r, m, nil); f != nil {
+		t.Fatalf("expected pass, got %v", f)
+	}
+}
+
+func TestCheckSecurity_CookieAPIKey(t *testing.T) {
+	v, router := newTestValidator(t, securitySpec)
+
+	r, m := routedRequest(t, router, http.MethodGet, "/cookie", "", nil)
+	if f := v.CheckSecurity(r, m, nil); f == nil {
+		t.Fatalf("expected failure without cookie")
+	}
+
+	r.AddCookie(&http.Cookie{Name: "sid", Value: "s"})
+	if f := v.CheckSecurity(r, m, map[string][]string{"cookieKey": {"s"}}); f != nil {
+		t.Fatalf("expected pass, got %v", f)
+	}
+}
+
+func TestCheckSecurity_OAuth2Scopes(t *testing.T) {
+	v, router := newTestValidator(t, securitySpec)
+	creds := map[string][]string{"oauth": {"reader(read)", "writer(read write)", "root"}}
+
+	cases := []struct {
+		token  string
+		status int
+	}{
+		{"writer", 0},
+		{"root", 0},
+		{"reader", http.StatusForbidden},
+		{"unknown", http.StatusUnauthorized},
+	}
+	for _, tc := range cases {
+		r, m := routedRequest(t, router, http.MethodGet, "/scoped", "", map[string]string{"Authorization": "Bearer " + tc.token})
+		f := v.CheckSecurity(r, m, creds)
+		switch {
+		case tc.status == 0 && f != nil:
+			t.Fatalf("%s: expected pass, got %v", tc.token, f)
+		case tc.status != 0 && (f == nil || f.Status != tc.status):
+			t.Fatalf("%s: expected %d, got %#v", tc.token, tc.status, f)
+		}
+	}
+
+	r, m := routedRequest(t, router, http.MethodGet, "/scoped", "", map[string]string{"Authorization": "Bearer reader"})
+	f := v.CheckSecurity(r, m, creds)
+	if f.Reason != "token lacks scope write" {
+		t.Fatalf("unexpected reason %q", f.Reason)
+	}
+	if f.Challenges[0] != `Bearer realm="openapi-emulator", error="insufficient_scope", scope="read write"` {
+		t.Fatalf("unexpected challenge %q", f.Challenges[0])
+	}
+}
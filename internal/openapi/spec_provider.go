@@ -15,6 +15,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/oasdiff/yaml"
 	"github.com/sirupsen/logrus"
 
 	"github.com/getkin/kin-openapi/openapi2"
@@ -23,9 +24,10 @@ import (
 )
 
 type SpecProvider struct {
-	path string
-	spec *Spec
-	log  *logrus.Logger
+	path   string
+	spec   *Spec
+	report *SpecReport
+	log    *logrus.Logger
 }
 
 func NewSpecProvider(path string, log *logrus.Logger) (ISpecProvider, error) {
@@ -34,8 +36,18 @@ func NewSpecProvider(path string, log *logrus.Logger) (ISpecProvider, error) {
 		return nil, fmt.Errorf("read spec: %w", err)
 	}
 
+	b, err = specToJSON(path, b)
+	if err != nil {
+		log.WithError(err).Warn("failed to convert yaml spec to json")
+		return nil, fmt.Errorf("parse spec yaml: %w", err)
+	}
+
 	var probe versionProbe
 	_ = json.Unmarshal(b, &probe)
+	b, err = quoteSpecVersions(b, probe)
+	if err != nil {
+		return nil, fmt.Errorf("parse spec version: %w", err)
+	}
 
 	abs, _ := filepath.Abs(path)
 	loc := &url.URL{Scheme: "file", Path: abs}
@@ -44,7 +56,7 @@ func NewSpecProvider(path string, log *logrus.Logger) (ISpecProvider, error) {
 	loader.IsExternalRefsAllowed = true
 
 	// Swagger 2.0
-	if probe.Swagger == "2.0" {
+	if specVersion(probe.Swagger) == "2.0" {
 		var doc2 openapi2.T
 		if err := json.Unmarshal(b, &doc2); err != nil {
 			return nil, fmt.Errorf("parse swagger2 json: %w", err)
@@ -60,15 +72,16 @@ func NewSpecProvider(path string, log *logrus.Logger) (ISpecProvider, error) {
 			log.WithError(err).Warn("failed to resolve swagger to v3")
 			return nil, fmt.Errorf("resolve refs: %w", err)
 		}
+		promoteParameterExamples(doc3)
 
-		if err := doc3.Validate(context.Background()); err != nil {
-			log.WithError(err).Warn("openapi spec validation failed")
-		}
+		report := validateSpec(doc3, log)
+		report.toSwagger2(&doc2)
 
 		return &SpecProvider{
-			path: path,
-			spec: &Spec{Doc2: &doc2, Doc3: doc3},
-			log:  log,
+			path:   path,
+			spec:   &Spec{Doc2: &doc2, Doc3: doc3},
+			report: report,
+			log:    log,
 		}, nil
 	}
 
@@ -82,41 +95,427 @@ func NewSpecProvider(path string, log *logrus.Logger) (ISpecProvider, error) {
 		log.WithError(err).Warn("failed to resolve swagger to v3")
 		return nil, fmt.Errorf("resolve refs: %w", err)
 	}
-	_ = doc3.Validate(context.Background())
 
 	return &SpecProvider{
-		path: path,
-		spec: &Spec{Doc3: &doc3},
-		log:  log,
+		path:   path,
+		spec:   &Spec{Doc3: &doc3},
+		report: validateSpec(&doc3, log),
+		log:    log,
 	}, nil
 }
 
+func validateSpec(doc3 *openapi3.T, log *logrus.Logger) *SpecReport {
+	report := ValidateSpec(context.Background(), doc3)
+	if !report.OK() {
+		log.WithField("issues", len(report.Issues)).Warn("openapi spec validation failed")
+	}
+	return report
+}
+
+// specToJSON returns the spec as JSON. YAML is detected by the file extension
+// (.yaml/.yml) or, for any other extension, by content that does not start
+// with a JSON object.
+func specToJSON(path string, b []byte) ([]byte, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return b, nil
+	case ".yaml", ".yml":
+		return yaml.YAMLToJSON(b)
+	}
+
+	if strings.HasPrefix(strings.TrimSpace(string(b)), "{") {
+		return b, nil
+	}
+	return yaml.YAMLToJSON(b)
+}
+
+// specVersion returns a version field of the spec as text. A number, as
+// YAML reads an unquoted 2.0, keeps at least one decimal: 2 is "2.0".
+func specVersion(v any) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case float64:
+		s := strconv.FormatFloat(t, 'f', -1, 64)
+		if !strings.Contains(s, ".") {
+			s += ".0"
+		}
+		return s
+	}
+	return ""
+}
+
+// quoteSpecVersions rewrites numeric swagger and openapi fields of the spec
+// as strings, so the spec parses as the version it declares.
+func quoteSpecVersions(b []byte, probe versionProbe) ([]byte, error) {
+	fields := map[string]any{"swagger": probe.Swagger, "openapi": probe.OpenAPI}
+	var doc map[string]json.RawMessage
+	for name, v := range fields {
+		if _, ok := v.(float64); !ok {
+			continue
+		}
+		if doc == nil {
+			if err := json.Unmarshal(b, &doc); err != nil {
+				return nil, err
+			}
+		}
+		doc[name] = json.RawMessage(strconv.Quote(specVersion(v)))
+	}
+	if doc == nil {
+		return b, nil
+	}
+	return json.Marshal(doc)
+}
+
 func (sp *SpecProvider) GetSpec() *Spec {
 	return sp.spec
 }
 
-func (p *SpecProvider) TryGetExampleBody(swaggerPath, method string) ([]byte, bool) {
+// ValidationReport returns the validation errors found while loading the spec.
+func (sp *SpecProvider) ValidationReport() *SpecReport {
+	return sp.report
+}
+
+// TryGetExample returns the example body of the best response for the
+// negotiated media type. When the spec has no example for the media type,
+// or none was negotiated, the JSON example of the response is used.
+// A preferred status or example name selects the response instead; it
+// reports false when the operation declares no such response or example.
+func (p *SpecProvider) TryGetExample(swaggerPath, method string, opts ExampleOptions) (*Example, bool) {
 	op := p.FindOperation(swaggerPath, method)
 	if op == nil || op.Responses == nil {
 		return nil, false
 	}
 
-	respRef := p.pickBestResponseRef(op.Responses)
+	preferred := opts.Status != 0 || opts.Name != ""
+	respRef := preferredResponse(op.Responses, opts)
 	if respRef == nil || respRef.Value == nil {
+		if preferred {
+			return nil, false
+		}
 		b, _ := json.Marshal(map[string]any{"ok": true})
-		return b, true
+		return &Example{MediaType: "application/json", Body: b}, true
 	}
 
-	if b, ok := p.extractExampleFromResponse(respRef.Value); ok {
-		return b, true
+	code := responseCode(op.Responses, respRef)
+	status := statusOf(code)
+	resp := respRef.Value
+	g := p.generator(opts)
+	headers := g.responseHeaders(resp)
+
+	if opts.Name != "" {
+		ex, ok := namedExample(resp, opts.Name, opts.MediaType)
+		if ok {
+			ex.Status, ex.Headers = status, headers
+		}
+		return ex, ok
 	}
 
-	if b, ok := p.generateFromResponseSchema(respRef.Value); ok {
-		return b, true
+	if bodiless(status) {
+		return &Example{Status: status, Headers: headers}, true
+	}
+	if len(resp.Content) == 0 {
+		if ex, ok := p.swagger2Example(swaggerPath, method, code, opts.MediaType); ok {
+			ex.Status, ex.Headers = status, headers
+			return ex, true
+		}
+		if preferred {
+			return &Example{Status: status, Headers: headers}, true
+		}
+	}
+	if opts.MediaType != "" {
+		if b, ok := g.mediaTypeBody(contentFor(resp.Content, opts.MediaType), opts.MediaType); ok {
+			return &Example{Status: status, MediaType: opts.MediaType, Headers: headers, Body: b}, true
+		}
+	}
+	if b, ok := p.responseBody(swaggerPath, method, code, resp, g); ok {
+		return &Example{Status: status, MediaType: "application/json", Headers: headers, Body: b}, true
 	}
 
 	b, _ := json.Marshal(map[string]any{"ok": true})
-	return b, true
+	return &Example{Status: status, MediaType: "application/json", Headers: headers, Body: b}, true
+}
+
+// statusOf returns the status code of a response code such as "201", the
+// first code of a range such as "2XX", or 0 for "default".
+func statusOf(code string) int {
+	if n, err := strconv.Atoi(code); err == nil {
+		return n
+	}
+	if len(code) == 3 && code[0] >= '1' && code[0] <= '5' && strings.EqualFold(code[1:], "XX") {
+		return int(code[0]-'0') * 100
+	}
+	return 0
+}
+
+// bodiless reports whether responses with status never have a body.
+func bodiless(status int) bool {
+	return (status >= 100 && status < 200) || status == 204 || status == 304
+}
+
+// preferredResponse returns the response for the preferred status (or its
+// range, then "default"), the first response with the preferred example
+// name, or the best response.
+func preferredResponse(resps *openapi3.Responses, opts ExampleOptions) *openapi3.ResponseRef {
+	switch {
+	case opts.Status != 0:
+		if r := resps.Status(opts.Status); r != nil {
+			return r
+		}
+		return resps.Default()
+	case opts.Name != "":
+		if r := bestResponseRef(resps); hasNamedExample(r, opts.Name) {
+			return r
+		}
+		for _, code := range sortedKeys(resps.Map()) {
+			if r := resps.Value(code); hasNamedExample(r, opts.Name) {
+				return r
+			}
+		}
+		return nil
+	}
+	return bestResponseRef(resps)
+}
+
+func hasNamedExample(ref *openapi3.ResponseRef, name string) bool {
+	if ref == nil || ref.Value == nil {
+		return false
+	}
+	for _, mt := range ref.Value.Content {
+		if ex := mt.Examples[name]; ex != nil && ex.Value != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// namedExample returns the example called name of resp, in the negotiated
+// media type if it has one, otherwise in the first media type declaring it.
+func namedExample(resp *openapi3.Response, name, mediaType string) (*Example, bool) {
+	for _, ct := range append([]string{mediaType}, sortedMediaTypes(resp.Content)...) {
+		mt := resp.Content[ct]
+		if mt == nil {
+			continue
+		}
+		ex := mt.Examples[name]
+		if ex == nil || ex.Value == nil {
+			continue
+		}
+		if isMediaRange(ct) {
+			ct = "application/json"
+		}
+		if b, ok := encodeBody(ex.Value.Value, mt.Schema, ct); ok {
+			return &Example{MediaType: ct, Body: b}, true
+		}
+	}
+	return nil, false
+}
+
+// mediaTypeBody returns the example of mt. String examples of non-JSON
+// media types are used as they are; JSON media types may fall back to a
+// schema-generated body.
+func (g *schemaGenerator) mediaTypeBody(mt *openapi3.MediaType, mediaType string) ([]byte, bool) {
+	if mt == nil {
+		return nil, false
+	}
+
+	if mt.Example != nil {
+		return encodeBody(mt.Example, mt.Schema, mediaType)
+	}
+	for _, name := range sortedKeys(mt.Examples) {
+		if ex := mt.Examples[name]; ex != nil && ex.Value != nil && ex.Value.Value != nil {
+			return encodeBody(ex.Value.Value, mt.Schema, mediaType)
+		}
+	}
+
+	if isBinaryMediaType(mediaType) {
+		return binaryPlaceholder(mediaType), true
+	}
+	if mt.Schema != nil {
+		return encodeBody(g.genBody(mt.Schema), mt.Schema, mediaType)
+	}
+	return nil, false
+}
+
+// contentFor returns the media type object of content for mediaType, or of
+// the first range such as "image/*" that covers it.
+func contentFor(content openapi3.Content, mediaType string) *openapi3.MediaType {
+	if mt := content[mediaType]; mt != nil {
+		return mt
+	}
+	for _, key := range sortedMediaTypes(content) {
+		if isMediaRange(key) && mediaRangeMatches(key, mediaType) {
+			return content[key]
+		}
+	}
+	return nil
+}
+
+// responseHeaders returns values for the headers resp declares, taken from
+// their examples or generated from their schemas. Content-Type follows the
+// media type and is skipped.
+func (g *schemaGenerator) responseHeaders(resp *openapi3.Response) map[string]string {
+	var out map[string]string
+	for _, name := range sortedKeys(resp.Headers) {
+		ref := resp.Headers[name]
+		if ref == nil || ref.Value == nil || strings.EqualFold(name, "content-type") {
+			continue
+		}
+		if v, ok := g.headerValue(name, &ref.Value.Parameter); ok {
+			if out == nil {
+				out = map[string]string{}
+			}
+			out[name] = v
+		}
+	}
+	return out
+}
+
+func (g *schemaGenerator) headerValue(name string, h *openapi3.Parameter) (string, bool) {
+	v := h.Example
+	if v == nil {
+		for _, n := range sortedKeys(h.Examples) {
+			if ex := h.Examples[n]; ex != nil && ex.Value != nil && ex.Value.Value != nil {
+				v = ex.Value.Value
+				break
+			}
+		}
+	}
+	if v == nil && h.Schema != nil && h.Schema.Value != nil {
+		v = h.Schema.Value.Example
+		if v == nil {
+			v = g.genProperty(name, h.Schema, map[string]bool{}, 0)
+		}
+	}
+	if v == nil {
+		return "", false
+	}
+	return simpleStyle(v), true
+}
+
+// simpleStyle serializes a value in the "simple" style of headers: array
+// items and object keys and values are joined with commas.
+func simpleStyle(v any) string {
+	switch t := v.(type) {
+	case []any:
+		parts := make([]string, len(t))
+		for i, item := range t {
+			parts[i] = simpleStyle(item)
+		}
+		return strings.Join(parts, ",")
+	case map[string]any:
+		var parts []string
+		for _, k := range sortedKeys(t) {
+			parts = append(parts, k, simpleStyle(t[k]))
+		}
+		return strings.Join(parts, ",")
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case nil:
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// IsJSONMediaType reports whether mediaType is application/json or another
+// JSON media type such as application/problem+json.
+func IsJSONMediaType(mediaType string) bool {
+	return mimeRank(baseMediaType(mediaType)) < 2
+}
+
+// TryGetStatusExample returns the example of the response declared for
+// status, or for its "4XX"-style range, with the media type and headers
+// the response declares. Error statuses fall back to the "default"
+// response. mediaType picks the content of the response when it declares
+// it; otherwise the first JSON media type is used. Unlike TryGetExample it
+// does not fall back to a placeholder body, so responses without content
+// or Swagger 2.0 examples report false.
+func (p *SpecProvider) TryGetStatusExample(swaggerPath, method string, status int, mediaType string) (*Example, bool) {
+	op := p.FindOperation(swaggerPath, method)
+	if op == nil || op.Responses == nil {
+		return nil, false
+	}
+
+	respRef := op.Responses.Status(status)
+	if respRef == nil && status >= 400 {
+		respRef = op.Responses.Default()
+	}
+	if respRef == nil || respRef.Value == nil {
+		return nil, false
+	}
+	resp := respRef.Value
+	code := responseCode(op.Responses, respRef)
+	g := p.generator(ExampleOptions{})
+
+	if len(resp.Content) == 0 {
+		ex, ok := p.swagger2Example(swaggerPath, method, code, mediaType)
+		if ok {
+			ex.Status, ex.Headers = status, g.responseHeaders(resp)
+		}
+		return ex, ok
+	}
+
+	if mediaType == "" || contentFor(resp.Content, mediaType) == nil {
+		mediaType = declaredMediaType(resp.Content)
+	}
+	ex := &Example{Status: status, MediaType: mediaType, Headers: g.responseHeaders(resp)}
+
+	if IsJSONMediaType(mediaType) {
+		if b, ok := p.responseBody(swaggerPath, method, code, resp, g); ok {
+			ex.Body = b
+			return ex, true
+		}
+	}
+	b, ok := g.mediaTypeBody(contentFor(resp.Content, mediaType), mediaType)
+	if !ok {
+		return nil, false
+	}
+	ex.Body = b
+	return ex, true
+}
+
+// declaredMediaType returns the media type a response with content is
+// written as: the first declared one, JSON first, or application/json when
+// only ranges such as "*/*" are declared.
+func declaredMediaType(content openapi3.Content) string {
+	for _, mt := range sortedMediaTypes(content) {
+		if !isMediaRange(mt) {
+			return mt
+		}
+	}
+	return "application/json"
+}
+
+// swagger2Example returns the Swagger 2.0 example of the response declared
+// under code, encoded for its media type. 2.0 responses with examples but
+// no schema have no content after conversion, so only Doc2 knows them.
+func (p *SpecProvider) swagger2Example(swaggerPath, method, code, mediaType string) (*Example, bool) {
+	mime, v, ok := swagger2ExampleValue(p.spec.Doc2, swaggerPath, method, code, mediaType)
+	if !ok {
+		return nil, false
+	}
+	b, ok := encodeBody(v, nil, mime)
+	if !ok {
+		return nil, false
+	}
+	return &Example{MediaType: mime, Body: b}, true
+}
+
+// responseBody returns the example of resp, or a body generated from its
+// schema.
+func (p *SpecProvider) responseBody(swaggerPath, method, code string, resp *openapi3.Response, g *schemaGenerator) ([]byte, bool) {
+	if b, ok := p.extractExampleFromResponse(resp); ok {
+		return b, true
+	}
+
+	// Swagger 2.0 examples are lost in conversion; read them from Doc2
+	if p.spec.Doc2 != nil {
+		if b, ok := swagger2ResponseExample(p.spec.Doc2, swaggerPath, method, code); ok {
+			return b, true
+		}
+	}
+
+	return g.generateFromResponseSchema(resp)
 }
 
 func (p *SpecProvider) FindOperation(swaggerPath, method string) *openapi3.Operation {
@@ -130,7 +529,9 @@ func (p *SpecProvider) FindOperation(swaggerPath, method string) *openapi3.Opera
 	return item.GetOperation(strings.ToUpper(method))
 }
 
-func (p *SpecProvider) pickBestResponseRef(resps *openapi3.Responses) *openapi3.ResponseRef {
+// bestResponseRef picks the response served by default: the first success
+// response, then "default", then any.
+func bestResponseRef(resps *openapi3.Responses) *openapi3.ResponseRef {
 	if resps == nil {
 		return nil
 	}
@@ -161,15 +562,25 @@ func (p *SpecProvider) pickBestResponseRef(resps *openapi3.Responses) *openapi3.
 		return r
 	}
 
-	// Otherwise: return any
-	for _, r := range resps.Map() {
-		if r != nil {
+	// Otherwise: the lowest declared code
+	for _, code := range sortedKeys(resps.Map()) {
+		if r := resps.Value(code); r != nil {
 			return r
 		}
 	}
 	return nil
 }
 
+// responseCode returns the status code under which ref is declared.
+func responseCode(resps *openapi3.Responses, ref *openapi3.ResponseRef) string {
+	for code, r := range resps.Map() {
+		if r == ref {
+			return code
+		}
+	}
+	return ""
+}
+
 func (p *SpecProvider) extractExampleFromResponse(resp *openapi3.Response) ([]byte, bool) {
 	if resp == nil || resp.Content == nil {
 		return nil, false
@@ -189,15 +600,15 @@ func (p *SpecProvider) extractExampleFromResponse(resp *openapi3.Response) ([]by
 			}
 		}
 
-		if len(mt.Examples) > 0 {
-			for _, exRef := range mt.Examples {
-				if exRef == nil || exRef.Value == nil {
-					continue
-				}
-				if exRef.Value.Value != nil {
-					if b, err := json.Marshal(exRef.Value.Value); err == nil {
-						return b, true
-					}
+		// named examples in name order, so the choice is stable
+		for _, name := range sortedKeys(mt.Examples) {
+			exRef := mt.Examples[name]
+			if exRef == nil || exRef.Value == nil {
+				continue
+			}
+			if exRef.Value.Value != nil {
+				if b, err := json.Marshal(exRef.Value.Value); err == nil {
+					return b, true
 				}
 			}
 		}
@@ -206,7 +617,7 @@ func (p *SpecProvider) extractExampleFromResponse(resp *openapi3.Response) ([]by
 	return nil, false
 }
 
-func (p *SpecProvider) generateFromResponseSchema(resp *openapi3.Response) ([]byte, bool) {
+func (g *schemaGenerator) generateFromResponseSchema(resp *openapi3.Response) ([]byte, bool) {
 	if resp == nil || resp.Content == nil {
 		return nil, false
 	}
@@ -217,77 +628,9 @@ func (p *SpecProvider) generateFromResponseSchema(resp *openapi3.Response) ([]by
 			continue
 		}
 
-		val := p.genFromSchemaRef(mt.Schema, map[string]bool{}, 0)
-		b, err := json.Marshal(val)
+		b, err := json.Marshal(g.genBody(mt.Schema))
 		return b, err == nil
 	}
 
 	return nil, false
 }
-
-func (p *SpecProvider) genFromSchemaRef(ref *openapi3.SchemaRef, visiting map[string]bool, depth int) any {
-	if depth > 6 || ref == nil || ref.Value == nil {
-		return map[string]any{}
-	}
-
-	s := ref.Value
-
-	// enum wins
-	if len(s.Enum) > 0 {
-		return s.Enum[0]
-	}
-
-	// ARRAY
-	if s.Type != nil && s.Type.Is("array") {
-		if s.Items == nil {
-			return []any{}
-		}
-		return []any{p.genFromSchemaRef(s.Items, visiting, depth+1)}
-	}
-
-	// OBJECT
-	if (s.Type != nil && s.Type.Is("object")) || len(s.Properties) > 0 || s.AdditionalProperties.Schema != nil {
-		return p.genObject(s, visiting, depth)
-	}
-
-	// PRIMITIVES
-	if s.Type != nil && s.Type.Is("string") {
-		if s.Format == "date-time" {
-			return "2026-01-28T00:00:00Z"
-		}
-		return "string"
-	}
-	if s.Type != nil && s.Type.Is("integer") {
-		return 0
-	}
-	if s.Type != nil && s.Type.Is("number") {
-		return 0.0
-	}
-	if s.Type != nil && s.Type.Is("boolean") {
-		return true
-	}
-
-	// fallback
-	return map[string]any{"ok": true}
-}
-
-func (p *SpecProvider) genObject(s *openapi3.Schema, visiting map[string]bool, depth int) any {
-	out := map[string]any{}
-
-	// additionalProperties: schema form
-	if s.AdditionalProperties.Schema != nil {
-		out["key"] = p.genFromSchemaRef(s.AdditionalProperties.Schema, visiting, depth+1)
-		return out
-	}
-
-	if s.AdditionalProperties.Has != nil && *s.AdditionalProperties.Has {
-		out["key"] = "value"
-	}
-
-	// properties
-	for name, prop := range s.Properties {
-		out[name] = p.genFromSchemaRef(prop, visiting, depth+1)
-	}
-
-	return out
-}
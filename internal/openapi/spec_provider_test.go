@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -94,6 +95,169 @@ func TestLoadSpec_Swagger2_OK(t *testing.T) {
 	}
 }
 
+func TestLoadSpec_OpenAPI3_YAML_OK(t *testing.T) {
+	dir := t.TempDir()
+	p := filepath.Join(dir, "oas3.yaml")
+
+	specYAML := `
+openapi: 3.0.3
+info:
+  title: t
+  version: "1"
+paths:
+  /health:
+    get:
+      responses:
+        "200":
+          $ref: "#/components/responses/Ok"
+components:
+  responses:
+    Ok:
+      description: ok
+      content:
+        application/json:
+          example:
+            ok: true
+`
+
+	if err := os.WriteFile(p, []byte(specYAML), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	provider, err := NewSpecProvider(p, logrus.New())
+	if err != nil {
+		t.Fatalf("NewSpecProvider: %v", err)
+	}
+
+	ex, ok := provider.TryGetExample("/health", "get", ExampleOptions{})
+	if !ok {
+		t.Fatalf("expected example from resolved $ref")
+	}
+	var m map[string]any
+	_ = json.Unmarshal(ex.Body, &m)
+	if m["ok"] != true {
+		t.Fatalf("unexpected: %#v", m)
+	}
+}
+
+func TestLoadSpec_Swagger2_YAML_OK(t *testing.T) {
+	dir := t.TempDir()
+	p := filepath.Join(dir, "swagger.yml")
+
+	specYAML := `
+swagger: "2.0"
+info:
+  title: t
+  version: "1"
+basePath: /
+paths:
+  /health:
+    get:
+      responses:
+        "200":
+          description: ok
+`
+
+	if err := os.WriteFile(p, []byte(specYAML), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	provider, err := NewSpecProvider(p, logrus.New())
+	if err != nil {
+		t.Fatalf("NewSpecProvider: %v", err)
+	}
+
+	sp, ok := provider.(*SpecProvider)
+	if !ok {
+		t.Fatalf("expected *SpecProvider, got %T", provider)
+	}
+	if sp.GetSpec().Doc2 == nil {
+		t.Fatalf("expected Doc2 for swagger 2.0 yaml")
+	}
+	if provider.FindOperation("/health", "get") == nil {
+		t.Fatalf("expected /health GET operation in converted doc3")
+	}
+}
+
+func TestLoadSpec_Swagger2_YAML_UnquotedVersion(t *testing.T) {
+	dir := t.TempDir()
+	p := filepath.Join(dir, "swagger.yaml")
+
+	specYAML := `
+swagger: 2.0
+info:
+  title: t
+  version: "1"
+basePath: /
+paths:
+  /health:
+    get:
+      responses:
+        "200":
+          description: ok
+`
+
+	if err := os.WriteFile(p, []byte(specYAML), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	provider, err := NewSpecProvider(p, logrus.New())
+	if err != nil {
+		t.Fatalf("NewSpecProvider: %v", err)
+	}
+
+	spec := provider.GetSpec()
+	if spec.Doc2 == nil {
+		t.Fatalf("expected Doc2 for an unquoted swagger 2.0 version")
+	}
+	if spec.Doc2.Swagger != "2.0" {
+		t.Fatalf("expected swagger version 2.0, got %q", spec.Doc2.Swagger)
+	}
+	if provider.FindOperation("/health", "get") == nil {
+		t.Fatalf("expected /health GET operation in converted doc3")
+	}
+}
+
+func TestLoadSpec_YAML_DetectedByContent(t *testing.T) {
+	dir := t.TempDir()
+	p := filepath.Join(dir, "spec")
+
+	specYAML := `
+openapi: 3.0.3
+info: {title: t, version: "1"}
+paths:
+  /health:
+    get:
+      responses:
+        "200": {description: ok}
+`
+
+	if err := os.WriteFile(p, []byte(specYAML), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	provider, err := NewSpecProvider(p, logrus.New())
+	if err != nil {
+		t.Fatalf("NewSpecProvider: %v", err)
+	}
+	if provider.FindOperation("/health", "get") == nil {
+		t.Fatalf("expected operation")
+	}
+}
+
+func TestLoadSpec_InvalidYAML(t *testing.T) {
+	dir := t.TempDir()
+	p := filepath.Join(dir, "bad.yaml")
+	if err := os.WriteFile(p, []byte("openapi: [3.0.3\n  paths: :"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	_, err := NewSpecProvider(p, logrus.New())
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+}
+
 func TestLoadSpec_InvalidJSON(t *testing.T) {
 	dir := t.TempDir()
 	p := filepath.Join(dir, "bad.json")
@@ -114,16 +278,14 @@ func TestLoadSpec_MissingFile(t *testing.T) {
 	}
 }
 
-func TestPickBestResponseRef_Prefers200Then201202204(t *testing.T) {
-	p := &SpecProvider{log: logrus.New()}
-
+func TestBestResponseRef_Prefers200Then201202204(t *testing.T) {
 	resps := openapi3.NewResponses()
 	resps.Set("201", &openapi3.ResponseRef{Value: &openapi3.Response{Description: ptr("created")}})
 	resps.Set("200", &openapi3.ResponseRef{Value: &openapi3.Response{Description: ptr("ok")}})
 	resps.Set("202", &openapi3.ResponseRef{Value: &openapi3.Response{Description: ptr("accepted")}})
 	resps.Set("204", &openapi3.ResponseRef{Value: &openapi3.Response{Description: ptr("nocontent")}})
 
-	got := p.pickBestResponseRef(resps)
+	got := bestResponseRef(resps)
 	if got == nil || got.Value == nil || got.Value.Description == nil || *got.Value.Description != "ok" {
 		t.Fatalf("expected 200, got %#v", got)
 	}
@@ -131,21 +293,19 @@ func TestPickBestResponseRef_Prefers200Then201202204(t *testing.T) {
 	resps = openapi3.NewResponses()
 	resps.Set("201", &openapi3.ResponseRef{Value: &openapi3.Response{Description: ptr("created")}})
 	resps.Set("202", &openapi3.ResponseRef{Value: &openapi3.Response{Description: ptr("accepted")}})
-	got = p.pickBestResponseRef(resps)
+	got = bestResponseRef(resps)
 	if got == nil || got.Value == nil || got.Value.Description == nil || *got.Value.Description != "created" {
 		t.Fatalf("expected 201, got %#v", got)
 	}
 }
 
-func TestPickBestResponseRef_PicksLowest2xx(t *testing.T) {
-	p := &SpecProvider{log: logrus.New()}
-
+func TestBestResponseRef_PicksLowest2xx(t *testing.T) {
 	resps := openapi3.NewResponses()
 	resps.Set("299", &openapi3.ResponseRef{Value: &openapi3.Response{Description: ptr("299")}})
 	resps.Set("250", &openapi3.ResponseRef{Value: &openapi3.Response{Description: ptr("250")}})
 	resps.Set("210", &openapi3.ResponseRef{Value: &openapi3.Response{Description: ptr("210")}})
 
-	got := p.pickBestResponseRef(resps)
+	got := bestResponseRef(resps)
 	if got == nil || got.Value == nil || got.Value.Description == nil || *got.Value.Description != "210" {
 		t.Fatalf("expected lowest 2xx=210, got %#v", got)
 	}
@@ -222,7 +382,7 @@ func TestExtractExampleFromResponse_NilGuards(t *testing.T) {
 }
 
 func TestGenerateFromResponseSchema_JSON(t *testing.T) {
-	p := &SpecProvider{log: logrus.New()}
+	g := &schemaGenerator{}
 
 	resp := &openapi3.Response{
 		Content: openapi3.Content{
@@ -237,7 +397,7 @@ func TestGenerateFromResponseSchema_JSON(t *testing.T) {
 		},
 	}
 
-	b, ok := p.generateFromResponseSchema(resp)
+	b, ok := g.generateFromResponseSchema(resp)
 	if !ok {
 		t.Fatalf("expected ok")
 	}
@@ -249,7 +409,7 @@ func TestGenerateFromResponseSchema_JSON(t *testing.T) {
 }
 
 func TestGenerateFromResponseSchema_ProblemJSON(t *testing.T) {
-	p := &SpecProvider{log: logrus.New()}
+	g := &schemaGenerator{}
 
 	resp := &openapi3.Response{
 		Content: openapi3.Content{
@@ -258,7 +418,7 @@ func TestGenerateFromResponseSchema_ProblemJSON(t *testing.T) {
 			},
 		},
 	}
-	b, ok := p.generateFromResponseSchema(resp)
+	b, ok := g.generateFromResponseSchema(resp)
 	if !ok {
 		t.Fatalf("expected ok")
 	}
@@ -270,7 +430,7 @@ func TestGenerateFromResponseSchema_ProblemJSON(t *testing.T) {
 }
 
 func TestGenerateFromResponseSchema_StarStar(t *testing.T) {
-	p := &SpecProvider{log: logrus.New()}
+	g := &schemaGenerator{}
 
 	resp := &openapi3.Response{
 		Content: openapi3.Content{
@@ -279,7 +439,7 @@ func TestGenerateFromResponseSchema_StarStar(t *testing.T) {
 			},
 		},
 	}
-	b, ok := p.generateFromResponseSchema(resp)
+	b, ok := g.generateFromResponseSchema(resp)
 	if !ok {
 		t.Fatalf("expected ok")
 	}
@@ -291,34 +451,34 @@ func TestGenerateFromResponseSchema_StarStar(t *testing.T) {
 }
 
 func TestGenerateFromResponseSchema_NoSchema(t *testing.T) {
-	p := &SpecProvider{log: logrus.New()}
+	g := &schemaGenerator{}
 
 	resp := &openapi3.Response{
 		Content: openapi3.Content{
 			"application/json": &openapi3.MediaType{},
 		},
 	}
-	_, ok := p.generateFromResponseSchema(resp)
+	_, ok := g.generateFromResponseSchema(resp)
 	if ok {
 		t.Fatalf("expected false")
 	}
 }
 
 func TestGenerateFromResponseSchema_NilGuards(t *testing.T) {
-	p := &SpecProvider{log: logrus.New()}
+	g := &schemaGenerator{}
 
-	if _, ok := p.generateFromResponseSchema(nil); ok {
+	if _, ok := g.generateFromResponseSchema(nil); ok {
 		t.Fatalf("expected false")
 	}
-	if _, ok := p.generateFromResponseSchema(&openapi3.Response{}); ok {
+	if _, ok := g.generateFromResponseSchema(&openapi3.Response{}); ok {
 		t.Fatalf("expected false")
 	}
 }
 
 func TestGenFromSchemaRef_EnumWins(t *testing.T) {
-	p := &SpecProvider{log: logrus.New()}
+	g := &schemaGenerator{}
 
-	v := p.genFromSchemaRef(&openapi3.SchemaRef{Value: &openapi3.Schema{
+	v := g.genFromSchemaRef(&openapi3.SchemaRef{Value: &openapi3.Schema{
 		Enum: []any{"a", "b"},
 	}}, map[string]bool{}, 0)
 
@@ -328,7 +488,7 @@ func TestGenFromSchemaRef_EnumWins(t *testing.T) {
 }
 
 func TestGenFromSchemaRef_Primitives(t *testing.T) {
-	p := &SpecProvider{log: logrus.New()}
+	g := &schemaGenerator{}
 
 	tests := []struct {
 		name string
@@ -344,7 +504,7 @@ func TestGenFromSchemaRef_Primitives(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			got := p.genFromSchemaRef(&openapi3.SchemaRef{Value: tc.s}, map[string]bool{}, 0)
+			got := g.genFromSchemaRef(&openapi3.SchemaRef{Value: tc.s}, map[string]bool{}, 0)
 			if got != tc.want {
 				t.Fatalf("got %#v want %#v", got, tc.want)
 			}
@@ -353,9 +513,9 @@ func TestGenFromSchemaRef_Primitives(t *testing.T) {
 }
 
 func TestGenFromSchemaRef_Array(t *testing.T) {
-	p := &SpecProvider{log: logrus.New()}
+	g := &schemaGenerator{}
 
-	got := p.genFromSchemaRef(&openapi3.SchemaRef{Value: &openapi3.Schema{
+	got := g.genFromSchemaRef(&openapi3.SchemaRef{Value: &openapi3.Schema{
 		Type:  &openapi3.Types{"array"},
 		Items: &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}}},
 	}}, map[string]bool{}, 0)
@@ -367,9 +527,9 @@ func TestGenFromSchemaRef_Array(t *testing.T) {
 }
 
 func TestGenFromSchemaRef_ObjectProperties(t *testing.T) {
-	p := &SpecProvider{log: logrus.New()}
+	g := &schemaGenerator{}
 
-	got := p.genFromSchemaRef(&openapi3.SchemaRef{Value: &openapi3.Schema{
+	got := g.genFromSchemaRef(&openapi3.SchemaRef{Value: &openapi3.Schema{
 		Type: &openapi3.Types{"object"},
 		Properties: openapi3.Schemas{
 			"id":   {Value: &openapi3.Schema{Type: &openapi3.Types{"integer"}}},
@@ -384,14 +544,14 @@ func TestGenFromSchemaRef_ObjectProperties(t *testing.T) {
 }
 
 func TestGenObject_AdditionalPropertiesSchema(t *testing.T) {
-	p := &SpecProvider{log: logrus.New()}
+	g := &schemaGenerator{}
 
 	s := &openapi3.Schema{}
 	s.AdditionalProperties.Schema = &openapi3.SchemaRef{
 		Value: &openapi3.Schema{Type: &openapi3.Types{"string"}},
 	}
 
-	got := p.genObject(s, map[string]bool{}, 0)
+	got := g.genObject(s, map[string]bool{}, 0)
 	m, ok := got.(map[string]any)
 	if !ok || m["key"] != "string" {
 		t.Fatalf("unexpected: %#v", got)
@@ -399,7 +559,7 @@ func TestGenObject_AdditionalPropertiesSchema(t *testing.T) {
 }
 
 func TestGenObject_AdditionalPropertiesTrue(t *testing.T) {
-	p := &SpecProvider{log: logrus.New()}
+	g := &schemaGenerator{}
 
 	s := &openapi3.Schema{
 		Properties: openapi3.Schemas{
@@ -409,7 +569,7 @@ func TestGenObject_AdditionalPropertiesTrue(t *testing.T) {
 	b := true
 	s.AdditionalProperties.Has = &b
 
-	got := p.genObject(s, map[string]bool{}, 0)
+	got := g.genObject(s, map[string]bool{}, 0)
 	m, ok := got.(map[string]any)
 	if !ok {
 		t.Fatalf("unexpected: %#v", got)
@@ -423,9 +583,9 @@ func TestGenObject_AdditionalPropertiesTrue(t *testing.T) {
 }
 
 func TestGenFromSchemaRef_DepthLimit(t *testing.T) {
-	p := &SpecProvider{log: logrus.New()}
+	g := &schemaGenerator{}
 
-	got := p.genFromSchemaRef(&openapi3.SchemaRef{Value: &openapi3.Schema{
+	got := g.genFromSchemaRef(&openapi3.SchemaRef{Value: &openapi3.Schema{
 		Type: &openapi3.Types{"object"},
 	}}, map[string]bool{}, 7)
 
@@ -436,32 +596,32 @@ func TestGenFromSchemaRef_DepthLimit(t *testing.T) {
 }
 
 func TestGenFromSchemaRef_NilGuards(t *testing.T) {
-	p := &SpecProvider{log: logrus.New()}
+	g := &schemaGenerator{}
 
-	got := p.genFromSchemaRef(nil, map[string]bool{}, 0)
+	got := g.genFromSchemaRef(nil, map[string]bool{}, 0)
 	if _, ok := got.(map[string]any); !ok {
 		t.Fatalf("expected map fallback, got %#v", got)
 	}
 
-	got = p.genFromSchemaRef(&openapi3.SchemaRef{Value: nil}, map[string]bool{}, 0)
+	got = g.genFromSchemaRef(&openapi3.SchemaRef{Value: nil}, map[string]bool{}, 0)
 	if _, ok := got.(map[string]any); !ok {
 		t.Fatalf("expected map fallback, got %#v", got)
 	}
 }
 
-func TestTryGetExampleBody_NoOperation(t *testing.T) {
+func TestTryGetExample_NoOperation(t *testing.T) {
 	p := &SpecProvider{
 		spec: &Spec{Doc3: &openapi3.T{}},
 		log:  logrus.New(),
 	}
 
-	_, ok := p.TryGetExampleBody("/missing", "get")
+	_, ok := p.TryGetExample("/missing", "get", ExampleOptions{})
 	if ok {
 		t.Fatalf("expected false when operation not found or responses nil")
 	}
 }
 
-func TestTryGetExampleBody_ResponseMissingValue_FallbackOK(t *testing.T) {
+func TestTryGetExample_ResponseMissingValue_FallbackOK(t *testing.T) {
 	paths := openapi3.NewPaths()
 	paths.Set("/x", &openapi3.PathItem{
 		Get: &openapi3.Operation{
@@ -480,19 +640,19 @@ func TestTryGetExampleBody_ResponseMissingValue_FallbackOK(t *testing.T) {
 		log:  logrus.New(),
 	}
 
-	b, ok := p.TryGetExampleBody("/x", "get")
+	ex, ok := p.TryGetExample("/x", "get", ExampleOptions{})
 	if !ok {
 		t.Fatalf("expected ok")
 	}
 
 	var m map[string]any
-	_ = json.Unmarshal(b, &m)
+	_ = json.Unmarshal(ex.Body, &m)
 	if m["ok"] != true {
 		t.Fatalf("unexpected: %#v", m)
 	}
 }
 
-func TestTryGetExampleBody_ExplicitExampleWins(t *testing.T) {
+func TestTryGetExample_ExplicitExampleWins(t *testing.T) {
 	paths := openapi3.NewPaths()
 	paths.Set("/x", &openapi3.PathItem{
 		Get: &openapi3.Operation{
@@ -519,19 +679,19 @@ func TestTryGetExampleBody_ExplicitExampleWins(t *testing.T) {
 		log:  logrus.New(),
 	}
 
-	b, ok := p.TryGetExampleBody("/x", "get")
+	ex, ok := p.TryGetExample("/x", "get", ExampleOptions{})
 	if !ok {
 		t.Fatalf("expected ok")
 	}
 
 	var m map[string]any
-	_ = json.Unmarshal(b, &m)
+	_ = json.Unmarshal(ex.Body, &m)
 	if m["hello"] != "world" {
 		t.Fatalf("unexpected: %#v", m)
 	}
 }
 
-func TestTryGetExampleBody_SchemaGeneratedWhenNoExample(t *testing.T) {
+func TestTryGetExample_SchemaGeneratedWhenNoExample(t *testing.T) {
 	paths := openapi3.NewPaths()
 	paths.Set("/x", &openapi3.PathItem{
 		Get: &openapi3.Operation{
@@ -563,19 +723,19 @@ func TestTryGetExampleBody_SchemaGeneratedWhenNoExample(t *testing.T) {
 		log:  logrus.New(),
 	}
 
-	b, ok := p.TryGetExampleBody("/x", "get")
+	ex, ok := p.TryGetExample("/x", "get", ExampleOptions{})
 	if !ok {
 		t.Fatalf("expected ok")
 	}
 
 	var m map[string]any
-	_ = json.Unmarshal(b, &m)
+	_ = json.Unmarshal(ex.Body, &m)
 	if m["id"] != float64(0) {
 		t.Fatalf("unexpected: %#v", m)
 	}
 }
 
-func TestTryGetExampleBody_FallbackOk(t *testing.T) {
+func TestTryGetExample_FallbackOk(t *testing.T) {
 	paths := openapi3.NewPaths()
 	paths.Set("/health", &openapi3.PathItem{
 		Get: &openapi3.Operation{
@@ -589,13 +749,13 @@ func TestTryGetExampleBody_FallbackOk(t *testing.T) {
 		log:  logrus.New(),
 	}
 
-	b, ok := p.TryGetExampleBody("/health", "get")
+	ex, ok := p.TryGetExample("/health", "get", ExampleOptions{})
 	if !ok {
 		t.Fatalf("expected ok")
 	}
 
 	var m map[string]any
-	_ = json.Unmarshal(b, &m)
+	_ = json.Unmarshal(ex.Body, &m)
 	if m["ok"] != true {
 		t.Fatalf("unexpected: %#v", m)
 	}
@@ -643,3 +803,310 @@ func TestNewSpecProvider_GetSpec_OpenAPI3(t *testing.T) {
 }
 
 func ptr(s string) *string { return &s }
+
+func TestTryGetStatusExample_RangesAndDefault(t *testing.T) {
+	jsonResponse := func(example any) *openapi3.ResponseRef {
+		return &openapi3.ResponseRef{Value: &openapi3.Response{
+			Content: openapi3.Content{
+				"application/json": &openapi3.MediaType{Example: example},
+			},
+		}}
+	}
+
+	paths := openapi3.NewPaths()
+	paths.Set("/x", &openapi3.PathItem{
+		Get: &openapi3.Operation{
+			Responses: func() *openapi3.Responses {
+				r := openapi3.NewResponses()
+				r.Set("200", jsonResponse(map[string]any{"ok": true}))
+				r.Set("401", jsonResponse(map[string]any{"code": "unauthorized"}))
+				r.Set("4XX", jsonResponse(map[string]any{"code": "client"}))
+				r.Set("500", &openapi3.ResponseRef{Value: &openapi3.Response{}})
+				r.Set("default", jsonResponse(map[string]any{"code": "default"}))
+				return r
+			}(),
+		},
+	})
+
+	p := &SpecProvider{
+		spec: &Spec{Doc3: &openapi3.T{Paths: paths}},
+		log:  logrus.New(),
+	}
+
+	cases := []struct {
+		status int
+		want   string
+		ok     bool
+	}{
+		{401, "unauthorized", true},
+		{403, "client", true},
+		{500, "", false},
+		{503, "default", true},
+		{302, "", false},
+	}
+	for _, tc := range cases {
+		ex, ok := p.TryGetStatusExample("/x", "get", tc.status, "")
+		if ok != tc.ok {
+			t.Fatalf("%d: expected ok=%v, got %v (%+v)", tc.status, tc.ok, ok, ex)
+		}
+		if !ok {
+			continue
+		}
+		b := ex.Body
+		var m map[string]any
+		_ = json.Unmarshal(b, &m)
+		if m["code"] != tc.want {
+			t.Fatalf("%d: unexpected body: %s", tc.status, b)
+		}
+	}
+
+	if _, ok := p.TryGetStatusExample("/missing", "get", 401, ""); ok {
+		t.Fatalf("expected false when operation not found")
+	}
+}
+
+func TestTryGetStatusExample(t *testing.T) {
+	paths := openapi3.NewPaths()
+	paths.Set("/x", &openapi3.PathItem{
+		Get: &openapi3.Operation{
+			Responses: func() *openapi3.Responses {
+				r := openapi3.NewResponsesWithCapacity(4)
+				r.Set("401", &openapi3.ResponseRef{Value: &openapi3.Response{
+					Headers: openapi3.Headers{
+						"WWW-Authenticate": &openapi3.HeaderRef{Value: &openapi3.Header{Parameter: openapi3.Parameter{
+							Example: `Bearer realm="api"`,
+						}}},
+					},
+					Content: openapi3.Content{
+						"application/problem+json": &openapi3.MediaType{Example: map[string]any{"title": "unauthorized"}},
+						"application/xml":          &openapi3.MediaType{Example: "<error/>"},
+					},
+				}})
+				r.Set("403", &openapi3.ResponseRef{Value: &openapi3.Response{
+					Content: openapi3.Content{
+						"*/*": &openapi3.MediaType{Example: map[string]any{"title": "forbidden"}},
+					},
+				}})
+				r.Set("500", &openapi3.ResponseRef{Value: &openapi3.Response{}})
+				return r
+			}(),
+		},
+	})
+	p := &SpecProvider{
+		spec: &Spec{Doc3: &openapi3.T{Paths: paths}},
+		log:  logrus.New(),
+	}
+
+	cases := []struct {
+		status    int
+		mediaType string
+		want      Example
+	}{
+		{401, "", Example{Status: 401, MediaType: "application/problem+json", Body: []byte(`{"title":"unauthorized"}`),
+			Headers: map[string]string{"WWW-Authenticate": `Bearer realm="api"`}}},
+		{401, "application/xml", Example{Status: 401, MediaType: "application/xml", Body: []byte(`<error/>`),
+			Headers: map[string]string{"WWW-Authenticate": `Bearer realm="api"`}}},
+		{401, "text/csv", Example{Status: 401, MediaType: "application/problem+json", Body: []byte(`{"title":"unauthorized"}`),
+			Headers: map[string]string{"WWW-Authenticate": `Bearer realm="api"`}}},
+		{403, "", Example{Status: 403, MediaType: "application/json", Body: []byte(`{"title":"forbidden"}`)}},
+	}
+	for _, tc := range cases {
+		ex, ok := p.TryGetStatusExample("/x", "get", tc.status, tc.mediaType)
+		if !ok {
+			t.Fatalf("%d %q: expected an example", tc.status, tc.mediaType)
+		}
+		if ex.Status != tc.want.Status || ex.MediaType != tc.want.MediaType || string(ex.Body) != string(tc.want.Body) ||
+			!reflect.DeepEqual(ex.Headers, tc.want.Headers) {
+			t.Fatalf("%d %q: expected %+v, got %+v (%s)", tc.status, tc.mediaType, tc.want, ex, ex.Body)
+		}
+	}
+
+	for _, status := range []int{500, 302} {
+		if ex, ok := p.TryGetStatusExample("/x", "get", status, ""); ok {
+			t.Fatalf("%d: expected no example, got %+v", status, ex)
+		}
+	}
+}
+
+func TestTryGetExample_MediaType(t *testing.T) {
+	paths := openapi3.NewPaths()
+	paths.Set("/x", &openapi3.PathItem{
+		Get: &openapi3.Operation{
+			Responses: func() *openapi3.Responses {
+				r := openapi3.NewResponses()
+				r.Set("200", &openapi3.ResponseRef{Value: &openapi3.Response{
+					Content: openapi3.Content{
+						"application/json": &openapi3.MediaType{Example: map[string]any{"format": "json"}},
+						"application/xml":  &openapi3.MediaType{Example: "<format>xml</format>"},
+						"application/vnd.api+json": &openapi3.MediaType{Schema: &openapi3.SchemaRef{Value: &openapi3.Schema{
+							Type:       &openapi3.Types{"object"},
+							Properties: openapi3.Schemas{"id": {Value: &openapi3.Schema{Type: &openapi3.Types{"integer"}}}},
+						}}},
+					},
+				}})
+				return r
+			}(),
+		},
+	})
+
+	p := &SpecProvider{
+		spec: &Spec{Doc3: &openapi3.T{Paths: paths}},
+		log:  logrus.New(),
+	}
+
+	cases := []struct {
+		mediaType string
+		wantType  string
+		wantBody  string
+	}{
+		{"", "application/json", `{"format":"json"}`},
+		{"application/xml", "application/xml", `<format>xml</format>`},
+		{"application/vnd.api+json", "application/vnd.api+json", `{"id":0}`},
+		{"text/csv", "application/json", `{"format":"json"}`},
+	}
+	for _, tc := range cases {
+		ex, ok := p.TryGetExample("/x", "get", ExampleOptions{MediaType: tc.mediaType})
+		if !ok || ex.MediaType != tc.wantType || string(ex.Body) != tc.wantBody {
+			t.Fatalf("%q: unexpected example %#v (%s)", tc.mediaType, ex, ex.Body)
+		}
+	}
+
+	if _, ok := p.TryGetExample("/missing", "get", ExampleOptions{}); ok {
+		t.Fatalf("expected false when operation not found")
+	}
+}
+
+func TestTryGetExample_PreferredStatusAndName(t *testing.T) {
+	named := func(examples map[string]any) openapi3.Examples {
+		out := openapi3.Examples{}
+		for name, v := range examples {
+			out[name] = &openapi3.ExampleRef{Value: &openapi3.Example{Value: v}}
+		}
+		return out
+	}
+
+	paths := openapi3.NewPaths()
+	paths.Set("/scans/{id}", &openapi3.PathItem{
+		Get: &openapi3.Operation{
+			Responses: func() *openapi3.Responses {
+				r := openapi3.NewResponsesWithCapacity(4)
+				r.Set("200", &openapi3.ResponseRef{Value: &openapi3.Response{Content: openapi3.Content{
+					"application/json": &openapi3.MediaType{Examples: named(map[string]any{
+						"scanRunning":  map[string]any{"status": "running"},
+						"scanFinished": map[string]any{"status": "finished"},
+					})},
+				}}})
+				r.Set("404", &openapi3.ResponseRef{Value: &openapi3.Response{Content: openapi3.Content{
+					"application/json": &openapi3.MediaType{Examples: named(map[string]any{
+						"scanNotFound": map[string]any{"code": "E_NOT_FOUND"},
+					})},
+				}}})
+				r.Set("5XX", &openapi3.ResponseRef{Value: &openapi3.Response{Content: openapi3.Content{
+					"application/json": &openapi3.MediaType{Example: map[string]any{"code": "E_SERVER"}},
+				}}})
+				r.Set("410", &openapi3.ResponseRef{Value: &openapi3.Response{}})
+				return r
+			}(),
+		},
+	})
+	p := &SpecProvider{spec: &Spec{Doc3: &openapi3.T{Paths: paths}}, log: logrus.New()}
+
+	cases := []struct {
+		name       string
+		opts       ExampleOptions
+		wantStatus int
+		wantBody   string
+	}{
+		{"default is the first named example", ExampleOptions{}, 200, `{"status":"finished"}`},
+		{"named example", ExampleOptions{Name: "scanRunning"}, 200, `{"status":"running"}`},
+		{"named example of another response", ExampleOptions{Name: "scanNotFound"}, 404, `{"code":"E_NOT_FOUND"}`},
+		{"status", ExampleOptions{Status: 404}, 404, `{"code":"E_NOT_FOUND"}`},
+		{"status range", ExampleOptions{Status: 503}, 500, `{"code":"E_SERVER"}`},
+		{"status without content", ExampleOptions{Status: 410}, 410, ``},
+	}
+	for _, tc := range cases {
+		for i := 0; i < 5; i++ {
+			ex, ok := p.TryGetExample("/scans/{id}", "get", tc.opts)
+			if !ok || ex.Status != tc.wantStatus || string(ex.Body) != tc.wantBody {
+				t.Fatalf("%s: unexpected example %#v (%s)", tc.name, ex, ex.Body)
+			}
+		}
+	}
+
+	for _, opts := range []ExampleOptions{
+		{Status: 401},
+		{Name: "missing"},
+		{Status: 404, Name: "scanRunning"},
+	} {
+		if ex, ok := p.TryGetExample("/scans/{id}", "get", opts); ok {
+			t.Fatalf("%+v: expected no example, got %s", opts, ex.Body)
+		}
+	}
+}
+
+func TestTryGetExample_StatusHeadersAndNoContent(t *testing.T) {
+	header := func(p openapi3.Parameter) *openapi3.HeaderRef {
+		return &openapi3.HeaderRef{Value: &openapi3.Header{Parameter: p}}
+	}
+
+	paths := openapi3.NewPaths()
+	paths.Set("/scans", &openapi3.PathItem{
+		Post: &openapi3.Operation{
+			Responses: func() *openapi3.Responses {
+				r := openapi3.NewResponsesWithCapacity(1)
+				r.Set("201", &openapi3.ResponseRef{Value: &openapi3.Response{
+					Headers: openapi3.Headers{
+						"Location":     header(openapi3.Parameter{Schema: &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Format: "uri"}}}),
+						"X-Request-Id": header(openapi3.Parameter{Example: "req-1"}),
+						"X-Rate-Limit": header(openapi3.Parameter{Schema: &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"integer"}, Min: float(10)}}}),
+						"X-Tags": header(openapi3.Parameter{Schema: &openapi3.SchemaRef{Value: &openapi3.Schema{
+							Type: &openapi3.Types{"array"}, MinItems: 2,
+							Items: &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Enum: []any{"a"}}},
+						}}}),
+						"Content-Type": header(openapi3.Parameter{Example: "text/plain"}),
+					},
+					Content: openapi3.Content{"application/json": &openapi3.MediaType{Example: map[string]any{"id": "1"}}},
+				}})
+				return r
+			}(),
+		},
+		Delete: &openapi3.Operation{
+			Responses: func() *openapi3.Responses {
+				r := openapi3.NewResponsesWithCapacity(1)
+				r.Set("204", &openapi3.ResponseRef{Value: &openapi3.Response{
+					Content: openapi3.Content{"application/json": &openapi3.MediaType{Example: map[string]any{"ignored": true}}},
+				}})
+				return r
+			}(),
+		},
+	})
+	p := &SpecProvider{spec: &Spec{Doc3: &openapi3.T{Paths: paths}}, log: logrus.New()}
+
+	ex, ok := p.TryGetExample("/scans", "post", ExampleOptions{})
+	if !ok || ex.Status != 201 || string(ex.Body) != `{"id":"1"}` {
+		t.Fatalf("unexpected example %#v", ex)
+	}
+	want := map[string]string{
+		"Location":     formatValues["uri"],
+		"X-Request-Id": "req-1",
+		"X-Rate-Limit": "10",
+		"X-Tags":       "a,a",
+	}
+	if !reflect.DeepEqual(ex.Headers, want) {
+		t.Fatalf("expected headers %v, got %v", want, ex.Headers)
+	}
+
+	ex, ok = p.TryGetExample("/scans", "delete", ExampleOptions{})
+	if !ok || ex.Status != 204 || ex.Body != nil || ex.MediaType != "" {
+		t.Fatalf("expected a bodiless 204, got %#v", ex)
+	}
+}
+
+func TestStatusOf(t *testing.T) {
+	cases := map[string]int{"201": 201, "2XX": 200, "4xx": 400, "default": 0, "": 0, "9XX": 0}
+	for in, want := range cases {
+		if got := statusOf(in); got != want {
+			t.Fatalf("%q: expected %d, got %d", in, want, got)
+		}
+	}
+}
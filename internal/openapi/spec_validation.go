@@ -0,0 +1,260 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openapi
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// SpecIssue is a single spec validation error located by a JSON pointer
+// into the OpenAPI 3 document. For Swagger 2.0 specs the provider maps it
+// back into the 2.0 document.
+type SpecIssue struct {
+	Pointer string
+	Message string
+}
+
+// SpecReport collects every validation error of a spec. openapi3.T.Validate
+// stops at the first error, so the document is validated part by part.
+type SpecReport struct {
+	Issues []SpecIssue
+}
+
+func (r *SpecReport) OK() bool {
+	return r == nil || len(r.Issues) == 0
+}
+
+func (r *SpecReport) String() string {
+	if r.OK() {
+		return "spec is valid"
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d spec validation issue(s):", len(r.Issues))
+	for _, is := range r.Issues {
+		fmt.Fprintf(&sb, "\n  %s: %s", is.Pointer, is.Message)
+	}
+	return sb.String()
+}
+
+func (r *SpecReport) add(pointer, msg string) {
+	if pointer == "" {
+		pointer = "/"
+	}
+	r.Issues = append(r.Issues, SpecIssue{Pointer: pointer, Message: msg})
+}
+
+func (r *SpecReport) check(pointer string, err error) {
+	if err != nil {
+		r.add(pointer, compactMessage(err.Error()))
+	}
+}
+
+// compactMessage keeps one line per schema error; kin-openapi appends the
+// full schema and value to each of them.
+func compactMessage(msg string) string {
+	parts := strings.Split(msg, " | ")
+	for i, p := range parts {
+		if idx := strings.Index(p, "\nSchema:"); idx >= 0 {
+			p = p[:idx]
+		}
+		parts[i] = strings.TrimSpace(p)
+	}
+	return strings.Join(parts, "; ")
+}
+
+type validatable interface {
+	Validate(ctx context.Context, opts ...openapi3.ValidationOption) error
+}
+
+// ValidateSpec validates doc and reports every error it finds.
+func ValidateSpec(ctx context.Context, doc *openapi3.T) *SpecReport {
+	r := &SpecReport{}
+	if doc == nil {
+		r.add("/", "spec is empty")
+		return r
+	}
+
+	if doc.OpenAPI == "" {
+		r.add("/openapi", "value of openapi must be a non-empty string")
+	}
+
+	if doc.Info == nil {
+		r.add("/info", "must be an object")
+	} else {
+		r.check("/info", doc.Info.Validate(ctx))
+	}
+
+	for i, srv := range doc.Servers {
+		if srv != nil {
+			r.check(jsonPointer("servers", strconv.Itoa(i)), srv.Validate(ctx))
+		}
+	}
+
+	if doc.Components != nil {
+		c := doc.Components
+		validateEach(ctx, r, "schemas", c.Schemas)
+		validateEach(ctx, r, "parameters", c.Parameters)
+		validateEach(ctx, r, "headers", c.Headers)
+		validateEach(ctx, r, "requestBodies", c.RequestBodies)
+		validateEach(ctx, r, "responses", c.Responses)
+		validateEach(ctx, r, "securitySchemes", c.SecuritySchemes)
+		validateEach(ctx, r, "examples", c.Examples)
+		validateEach(ctx, r, "links", c.Links)
+		validateEach(ctx, r, "callbacks", c.Callbacks)
+	}
+
+	if doc.Paths == nil || doc.Paths.Len() == 0 {
+		r.add("/paths", "must contain at least one path")
+	} else {
+		validatePaths(ctx, r, doc.Paths)
+	}
+
+	if doc.Security != nil {
+		r.check("/security", doc.Security.Validate(ctx))
+	}
+
+	return r
+}
+
+func validateEach[T validatable](ctx context.Context, r *SpecReport, kind string, m map[string]T) {
+	for _, name := range sortedKeys(m) {
+		v := m[name]
+		if isNil(v) {
+			continue
+		}
+		r.check(jsonPointer("components", kind, name), v.Validate(ctx))
+	}
+}
+
+func validatePaths(ctx context.Context, r *SpecReport, paths *openapi3.Paths) {
+	for _, path := range sortedKeys(paths.Map()) {
+		item := paths.Value(path)
+		base := jsonPointer("paths", path)
+
+		if !strings.HasPrefix(path, "/") {
+			r.add(base, "path does not start with a forward slash (/)")
+		}
+		if item == nil {
+			continue
+		}
+
+		for i, p := range item.Parameters {
+			if p != nil {
+				r.check(jsonPointer("paths", path, "parameters", strconv.Itoa(i)), p.Validate(ctx))
+			}
+		}
+
+		ops := item.Operations()
+		for _, method := range sortedKeys(ops) {
+			validateOperation(ctx, r, path, item, strings.ToLower(method), ops[method])
+		}
+	}
+}
+
+func validateOperation(ctx context.Context, r *SpecReport, path string, item *openapi3.PathItem, method string, op *openapi3.Operation) {
+	for i, p := range op.Parameters {
+		if p != nil {
+			r.check(jsonPointer("paths", path, method, "parameters", strconv.Itoa(i)), p.Validate(ctx))
+		}
+	}
+
+	if op.RequestBody != nil {
+		r.check(jsonPointer("paths", path, method, "requestBody"), op.RequestBody.Validate(ctx))
+	}
+
+	if op.Responses == nil || op.Responses.Len() == 0 {
+		r.add(jsonPointer("paths", path, method, "responses"), "must contain at least one response")
+	} else {
+		resps := op.Responses.Map()
+		for _, code := range sortedKeys(resps) {
+			if resps[code] != nil {
+				r.check(jsonPointer("paths", path, method, "responses", code), resps[code].Validate(ctx))
+			}
+		}
+	}
+
+	if missing := missingPathParams(path, item, op); len(missing) > 0 {
+		r.add(jsonPointer("paths", path, method, "parameters"),
+			fmt.Sprintf("path parameters must be declared exactly once (mismatch: %s)", strings.Join(missing, ", ")))
+	}
+}
+
+// missingPathParams compares the {params} of the path template with the
+// path parameters declared on the path item and the operation.
+func missingPathParams(path string, item *openapi3.PathItem, op *openapi3.Operation) []string {
+	inTemplate := map[string]bool{}
+	for _, seg := range strings.Split(path, "/") {
+		if name, ok := pathParamName(seg); ok {
+			inTemplate[name] = true
+		}
+	}
+
+	declared := map[string]bool{}
+	for _, params := range []openapi3.Parameters{item.Parameters, op.Parameters} {
+		for _, p := range params {
+			if p != nil && p.Value != nil && p.Value.In == openapi3.ParameterInPath {
+				declared[p.Value.Name] = true
+			}
+		}
+	}
+
+	var out []string
+	for name := range inTemplate {
+		if !declared[name] {
+			out = append(out, name)
+		}
+	}
+	for name := range declared {
+		if !inTemplate[name] {
+			out = append(out, name)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func pathParamName(seg string) (string, bool) {
+	if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+		return strings.TrimSuffix(strings.TrimPrefix(seg, "{"), "}"), true
+	}
+	return "", false
+}
+
+// jsonPointer builds an RFC 6901 pointer from unescaped tokens.
+func jsonPointer(tokens ...string) string {
+	var sb strings.Builder
+	for _, t := range tokens {
+		t = strings.ReplaceAll(t, "~", "~0")
+		t = strings.ReplaceAll(t, "/", "~1")
+		sb.WriteString("/")
+		sb.WriteString(t)
+	}
+	return sb.String()
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func isNil(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Pointer && rv.IsNil()
+}
@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/sirupsen/logrus"
+)
+
+func loadDoc3(t *testing.T, specJSON string) *openapi3.T {
+	t.Helper()
+	var doc openapi3.T
+	if err := json.Unmarshal([]byte(specJSON), &doc); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if err := openapi3.NewLoader().ResolveRefsIn(&doc, nil); err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	return &doc
+}
+
+func TestValidateSpec_Valid(t *testing.T) {
+	doc := loadDoc3(t, `{
+	  "openapi":"3.0.3",
+	  "info":{"title":"t","version":"1"},
+	  "paths":{
+		"/items/{id}":{
+		  "get":{
+			"parameters":[{"name":"id","in":"path","required":true,"schema":{"type":"string"}}],
+			"responses":{"200":{"description":"ok"}}
+		  }
+		}
+	  }
+	}`)
+
+	r := ValidateSpec(context.Background(), doc)
+	if !r.OK() {
+		t.Fatalf("expected valid spec, got %s", r)
+	}
+	if r.String() != "spec is valid" {
+		t.Fatalf("unexpected summary: %q", r.String())
+	}
+}
+
+func TestValidateSpec_ReportsEveryIssueWithPointer(t *testing.T) {
+	doc := loadDoc3(t, `{
+	  "openapi":"3.0.3",
+	  "info":{"title":"t"},
+	  "paths":{
+		"/items/{id}":{
+		  "get":{
+			"responses":{"200":{"description":"ok"}}
+		  },
+		  "post":{
+			"parameters":[{"name":"q","in":"nowhere"}],
+			"responses":{}
+		  }
+		}
+	  },
+	  "components":{
+		"schemas":{
+		  "Bad":{"type":"string","pattern":"(["}
+		}
+	  }
+	}`)
+
+	r := ValidateSpec(context.Background(), doc)
+
+	want := []string{
+		"/info",
+		"/components/schemas/Bad",
+		"/paths/~1items~1{id}/get/parameters",
+		"/paths/~1items~1{id}/post/parameters/0",
+		"/paths/~1items~1{id}/post/responses",
+	}
+	got := map[string]string{}
+	for _, is := range r.Issues {
+		got[is.Pointer] = is.Message
+	}
+	for _, p := range want {
+		if _, ok := got[p]; !ok {
+			t.Fatalf("expected issue at %s, got:\n%s", p, r)
+		}
+	}
+	if !strings.Contains(got["/paths/~1items~1{id}/get/parameters"], "id") {
+		t.Fatalf("expected missing path param name in message, got %q", got["/paths/~1items~1{id}/get/parameters"])
+	}
+	if !strings.HasPrefix(r.String(), "6 spec validation issue(s):") {
+		t.Fatalf("unexpected summary:\n%s", r)
+	}
+}
+
+func TestJSONPointer_Escapes(t *testing.T) {
+	got := jsonPointer("paths", "/a~b/{id}", "get")
+	want := "/paths/~1a~0b~1{id}/get"
+	if got != want {
+		t.Fatalf("got %q want %q", got, want)
+	}
+}
+
+func TestCompactMessage_DropsSchemaDump(t *testing.T) {
+	msg := "example x: Error at \"/a\": value must be a string\nSchema:\n  {}\n | Error at \"/b\": bad\nSchema:\n  {}"
+	got := compactMessage(msg)
+	want := `example x: Error at "/a": value must be a string; Error at "/b": bad`
+	if got != want {
+		t.Fatalf("got %q want %q", got, want)
+	}
+}
+
+func TestNewSpecProvider_ExposesValidationReport(t *testing.T) {
+	dir := t.TempDir()
+	p := filepath.Join(dir, "spec.json")
+	spec := `{
+	  "openapi":"3.0.3",
+	  "info":{"title":"t","version":"1"},
+	  "paths":{"/items/{id}":{"get":{"responses":{"200":{"description":"ok"}}}}}
+	}`
+	if err := os.WriteFile(p, []byte(spec), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	provider, err := NewSpecProvider(p, logrus.New())
+	if err != nil {
+		t.Fatalf("NewSpecProvider: %v", err)
+	}
+	sp, ok := provider.(*SpecProvider)
+	if !ok {
+		t.Fatalf("expected *SpecProvider, got %T", provider)
+	}
+
+	r := sp.ValidationReport()
+	if r.OK() || r.Issues[0].Pointer != "/paths/~1items~1{id}/get/parameters" {
+		t.Fatalf("unexpected report: %s", r)
+	}
+}
@@ -0,0 +1,253 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openapi
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// openapi2conv drops the 2.0 response "examples" map and keeps parameter
+// "x-example" only as an extension. The helpers below read them back.
+
+// swagger2ResponseExample returns the 2.0 example of the given response,
+// preferring application/json, then other JSON media types, then any.
+func swagger2ResponseExample(doc2 *openapi2.T, swaggerPath, method, code string) ([]byte, bool) {
+	_, v, ok := swagger2ExampleValue(doc2, swaggerPath, method, code, "")
+	if !ok {
+		return nil, false
+	}
+	b, err := json.Marshal(v)
+	return b, err == nil
+}
+
+// swagger2ExampleValue returns the media type and value of the 2.0 example
+// of the given response: the one for mediaType if there is one, otherwise
+// the first in the order of swagger2ResponseExample.
+func swagger2ExampleValue(doc2 *openapi2.T, swaggerPath, method, code, mediaType string) (string, any, bool) {
+	resp := swagger2Response(doc2, swaggerPath, method, code)
+	if resp == nil || len(resp.Examples) == 0 {
+		return "", nil, false
+	}
+	if v := resp.Examples[mediaType]; v != nil {
+		return mediaType, v, true
+	}
+
+	mimes := make([]string, 0, len(resp.Examples))
+	for mime := range resp.Examples {
+		mimes = append(mimes, mime)
+	}
+	sort.SliceStable(mimes, func(i, j int) bool {
+		return mimeRank(mimes[i]) < mimeRank(mimes[j]) ||
+			(mimeRank(mimes[i]) == mimeRank(mimes[j]) && mimes[i] < mimes[j])
+	})
+
+	for _, mime := range mimes {
+		if v := resp.Examples[mime]; v != nil {
+			return mime, v, true
+		}
+	}
+	return "", nil, false
+}
+
+func mimeRank(mime string) int {
+	mime = strings.ToLower(mime)
+	switch {
+	case mime == "application/json":
+		return 0
+	case strings.HasSuffix(mime, "+json") || strings.HasSuffix(mime, "/json"):
+		return 1
+	default:
+		return 2
+	}
+}
+
+func swagger2Response(doc2 *openapi2.T, swaggerPath, method, code string) *openapi2.Response {
+	if doc2 == nil || code == "" {
+		return nil
+	}
+	item := doc2.Paths[swaggerPath]
+	if item == nil {
+		return nil
+	}
+	op := item.Operations()[strings.ToUpper(method)]
+	if op == nil {
+		return nil
+	}
+
+	resp := op.Responses[code]
+	if resp != nil && resp.Ref != "" {
+		name := strings.TrimPrefix(resp.Ref, "#/responses/")
+		resp = doc2.Responses[name]
+	}
+	return resp
+}
+
+// promoteParameterExamples copies "x-example" of converted 2.0 parameters
+// into Parameter.Example where no example is set.
+func promoteParameterExamples(doc3 *openapi3.T) {
+	if doc3 == nil {
+		return
+	}
+
+	promote := func(params openapi3.Parameters) {
+		for _, ref := range params {
+			if ref == nil || ref.Value == nil || ref.Value.Example != nil {
+				continue
+			}
+			if ex, ok := ref.Value.Extensions["x-example"]; ok {
+				ref.Value.Example = ex
+			}
+		}
+	}
+
+	if doc3.Components != nil {
+		for _, ref := range doc3.Components.Parameters {
+			promote(openapi3.Parameters{ref})
+		}
+	}
+	if doc3.Paths == nil {
+		return
+	}
+	for _, item := range doc3.Paths.Map() {
+		if item == nil {
+			continue
+		}
+		promote(item.Parameters)
+		for _, op := range item.Operations() {
+			promote(op.Parameters)
+		}
+	}
+}
+
+// toSwagger2 rewrites the pointers of a report on the OAS3 document
+// converted from doc2 so they locate the issue in doc2 itself: schemas
+// under /definitions, request bodies at their "in: body" parameter.
+func (r *SpecReport) toSwagger2(doc2 *openapi2.T) {
+	if r == nil || doc2 == nil {
+		return
+	}
+	for i := range r.Issues {
+		r.Issues[i].Pointer = swagger2Pointer(doc2, r.Issues[i].Pointer)
+	}
+}
+
+func swagger2Pointer(doc2 *openapi2.T, pointer string) string {
+	t := pointerTokens(pointer)
+	if len(t) == 0 {
+		return pointer
+	}
+
+	switch t[0] {
+	case "openapi":
+		return "/swagger"
+	case "servers":
+		return "/host"
+	case "components":
+		if len(t) < 3 {
+			return pointer
+		}
+		section := map[string]string{
+			"schemas":         "definitions",
+			"parameters":      "parameters",
+			"requestBodies":   "parameters",
+			"responses":       "responses",
+			"securitySchemes": "securityDefinitions",
+		}[t[1]]
+		// formData parameters of /parameters end up as schemas
+		if t[1] == "schemas" && doc2.Definitions[t[2]] == nil && doc2.Parameters[t[2]] != nil {
+			section = "parameters"
+		}
+		if section == "" {
+			return pointer
+		}
+		return jsonPointer(append([]string{section}, t[2:]...)...)
+	case "paths":
+		return swagger2PathPointer(doc2, t, pointer)
+	}
+	return pointer
+}
+
+func swagger2PathPointer(doc2 *openapi2.T, t []string, pointer string) string {
+	if len(t) < 4 {
+		return pointer
+	}
+	item := doc2.Paths[t[1]]
+	if item == nil {
+		return pointer
+	}
+	if t[2] == "parameters" {
+		if i, ok := swagger2ParamIndex(doc2, item.Parameters, t[3], false); ok {
+			return jsonPointer(t[0], t[1], t[2], strconv.Itoa(i))
+		}
+		return pointer
+	}
+
+	op := item.Operations()[strings.ToUpper(t[2])]
+	if op == nil {
+		return pointer
+	}
+	switch {
+	case t[3] == "requestBody":
+		if i, ok := swagger2ParamIndex(doc2, op.Parameters, "", true); ok {
+			return jsonPointer(t[0], t[1], t[2], "parameters", strconv.Itoa(i))
+		}
+	case t[3] == "parameters" && len(t) > 4:
+		if i, ok := swagger2ParamIndex(doc2, op.Parameters, t[4], false); ok {
+			return jsonPointer(t[0], t[1], t[2], t[3], strconv.Itoa(i))
+		}
+	}
+	return pointer
+}
+
+// swagger2ParamIndex returns the index in params of the first body or
+// formData parameter when body is set, or else of the parameter at index
+// of the converted parameters, which leave those out.
+func swagger2ParamIndex(doc2 *openapi2.T, params openapi2.Parameters, index string, body bool) (int, bool) {
+	want, err := strconv.Atoi(index)
+	if !body && err != nil {
+		return 0, false
+	}
+	n := 0
+	for i, p := range params {
+		if p == nil {
+			continue
+		}
+		in := p.In
+		if p.Ref != "" {
+			if ref := doc2.Parameters[strings.TrimPrefix(p.Ref, "#/parameters/")]; ref != nil {
+				in = ref.In
+			}
+		}
+		isBody := in == "body" || in == "formData"
+		switch {
+		case body && isBody:
+			return i, true
+		case !body && !isBody:
+			if n == want {
+				return i, true
+			}
+			n++
+		}
+	}
+	return 0, false
+}
+
+// pointerTokens splits an RFC 6901 pointer into unescaped tokens.
+func pointerTokens(pointer string) []string {
+	if pointer == "" || pointer == "/" {
+		return nil
+	}
+	t := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i := range t {
+		t[i] = strings.ReplaceAll(strings.ReplaceAll(t[i], "~1", "/"), "~0", "~")
+	}
+	return t
+}
@@ -0,0 +1,194 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi2"
+)
+
+const swagger2WithExamples = `{
+  "swagger":"2.0",
+  "info":{"title":"t","version":"1"},
+  "produces":["application/json"],
+  "paths":{
+	"/scans/{id}":{
+	  "get":{
+		"parameters":[{"name":"id","in":"path","required":true,"type":"string","x-example":"scan-42"}],
+		"responses":{
+		  "200":{
+			"description":"ok",
+			"schema":{"type":"object","properties":{"status":{"type":"string"}}},
+			"examples":{
+			  "text/plain":"running",
+			  "application/json":{"status":"running"}
+			}
+		  },
+		  "404":{
+			"description":"missing",
+			"examples":{"application/json":{"error":"scan not found"}}
+		  }
+		}
+	  }
+	},
+	"/scans":{
+	  "get":{
+		"responses":{"200":{"$ref":"#/responses/ScanList"}}
+	  }
+	}
+  },
+  "responses":{
+	"ScanList":{
+	  "description":"list",
+	  "schema":{"type":"array","items":{"type":"string"}},
+	  "examples":{"application/vnd.scans+json":["a","b"]}
+	}
+  }
+}`
+
+func TestTryGetExample_Swagger2ResponseExamples(t *testing.T) {
+	sp := newTestProvider(t, swagger2WithExamples)
+
+	ex, ok := sp.TryGetExample("/scans/{id}", "get", ExampleOptions{})
+	if !ok {
+		t.Fatalf("expected ok")
+	}
+	var m map[string]any
+	_ = json.Unmarshal(ex.Body, &m)
+	if m["status"] != "running" {
+		t.Fatalf("expected application/json example from Doc2, got %s", ex.Body)
+	}
+}
+
+func TestTryGetExample_Swagger2ResponseRefExamples(t *testing.T) {
+	sp := newTestProvider(t, swagger2WithExamples)
+
+	ex, ok := sp.TryGetExample("/scans", "get", ExampleOptions{})
+	if !ok {
+		t.Fatalf("expected ok")
+	}
+	if string(ex.Body) != `["a","b"]` {
+		t.Fatalf("expected example of referenced response, got %s", ex.Body)
+	}
+}
+
+func TestTryGetExample_Swagger2ExamplesOnlyResponse(t *testing.T) {
+	sp := newTestProvider(t, swagger2WithExamples)
+
+	ex, ok := sp.TryGetExample("/scans/{id}", "get", ExampleOptions{Status: 404})
+	if !ok {
+		t.Fatalf("expected ok")
+	}
+	if ex.Status != 404 || ex.MediaType != "application/json" || string(ex.Body) != `{"error":"scan not found"}` {
+		t.Fatalf("expected the 2.0 example of the 404 response, got %d %q %s", ex.Status, ex.MediaType, ex.Body)
+	}
+}
+
+func TestPromoteParameterExamples_XExample(t *testing.T) {
+	sp := newTestProvider(t, swagger2WithExamples)
+
+	op := sp.FindOperation("/scans/{id}", "get")
+	if op == nil || len(op.Parameters) != 1 {
+		t.Fatalf("expected one parameter, got %#v", op)
+	}
+	if got := op.Parameters[0].Value.Example; got != "scan-42" {
+		t.Fatalf("expected x-example promoted, got %#v", got)
+	}
+}
+
+func TestValidationReport_Swagger2Pointers(t *testing.T) {
+	sp := newTestProvider(t, `{
+	  "swagger":"2.0",
+	  "info":{"title":"t","version":"1"},
+	  "definitions":{"Item":{"type":"object","properties":{"name":{"type":"string","pattern":"["}}}},
+	  "paths":{
+	    "/items/{id}":{"post":{
+	      "parameters":[
+	        {"name":"body","in":"body","schema":{"type":"nope"}},
+	        {"name":"q","in":"query","type":"string","pattern":"("}
+	      ],
+	      "responses":{"200":{"description":"ok"}}
+	    }}
+	  }
+	}`)
+
+	got := map[string]bool{}
+	for _, is := range sp.ValidationReport().Issues {
+		got[is.Pointer] = true
+	}
+	for _, want := range []string{
+		"/definitions/Item",
+		"/paths/~1items~1{id}/post/parameters/0",
+		"/paths/~1items~1{id}/post/parameters/1",
+		"/paths/~1items~1{id}/post/parameters",
+	} {
+		if !got[want] {
+			t.Fatalf("expected pointer %s into the 2.0 document, got %v", want, got)
+		}
+	}
+}
+
+func TestSwagger2Pointer(t *testing.T) {
+	doc2 := &openapi2.T{
+		Parameters: map[string]*openapi2.Parameter{"Payload": {In: "body", Name: "payload"}},
+		Paths: map[string]*openapi2.PathItem{
+			"/x/{id}": {
+				Parameters: openapi2.Parameters{{In: "path", Name: "id"}},
+				Post: &openapi2.Operation{Parameters: openapi2.Parameters{
+					{In: "header", Name: "X-A"},
+					{Ref: "#/parameters/Payload"},
+					{In: "query", Name: "q"},
+				}},
+			},
+		},
+	}
+	cases := map[string]string{
+		"/openapi":                            "/swagger",
+		"/components/schemas/Item":            "/definitions/Item",
+		"/components/requestBodies/Payload":   "/parameters/Payload",
+		"/components/securitySchemes/key":     "/securityDefinitions/key",
+		"/paths/~1x~1{id}/parameters/0":       "/paths/~1x~1{id}/parameters/0",
+		"/paths/~1x~1{id}/post/requestBody":   "/paths/~1x~1{id}/post/parameters/1",
+		"/paths/~1x~1{id}/post/parameters/1":  "/paths/~1x~1{id}/post/parameters/2",
+		"/paths/~1x~1{id}/post/responses/200": "/paths/~1x~1{id}/post/responses/200",
+		"/paths/~1missing/get/requestBody":    "/paths/~1missing/get/requestBody",
+		"/":                                   "/",
+	}
+	for in, want := range cases {
+		if got := swagger2Pointer(doc2, in); got != want {
+			t.Fatalf("%s: expected %s, got %s", in, want, got)
+		}
+	}
+}
+
+func TestSwagger2ResponseExample_Guards(t *testing.T) {
+	if _, ok := swagger2ResponseExample(nil, "/x", "get", "200"); ok {
+		t.Fatalf("expected false for nil doc")
+	}
+
+	doc2 := &openapi2.T{Paths: map[string]*openapi2.PathItem{
+		"/x": {Get: &openapi2.Operation{Responses: map[string]*openapi2.Response{
+			"200": {Description: "ok"},
+		}}},
+	}}
+	if _, ok := swagger2ResponseExample(doc2, "/x", "get", "200"); ok {
+		t.Fatalf("expected false without examples")
+	}
+	if _, ok := swagger2ResponseExample(doc2, "/x", "trace", "200"); ok {
+		t.Fatalf("expected false for unknown method")
+	}
+	if _, ok := swagger2ResponseExample(doc2, "/x", "get", ""); ok {
+		t.Fatalf("expected false for unknown code")
+	}
+}
+
+func TestMimeRank(t *testing.T) {
+	if !(mimeRank("application/json") < mimeRank("application/problem+json") &&
+		mimeRank("application/problem+json") < mimeRank("text/plain")) {
+		t.Fatalf("unexpected mime ranking")
+	}
+}
@@ -22,10 +22,16 @@ type MockSpecProvider struct {
 	mock.Mock
 }
 
-func (m *MockSpecProvider) TryGetExampleBody(swaggerPath, method string) ([]byte, bool) {
-	args := m.Called(swaggerPath, method)
-	b, _ := args.Get(0).([]byte)
-	return b, args.Bool(1)
+func (m *MockSpecProvider) TryGetExample(swaggerPath, method string, opts ExampleOptions) (*Example, bool) {
+	args := m.Called(swaggerPath, method, opts)
+	ex, _ := args.Get(0).(*Example)
+	return ex, args.Bool(1)
+}
+
+func (m *MockSpecProvider) TryGetStatusExample(swaggerPath, method string, status int, mediaType string) (*Example, bool) {
+	args := m.Called(swaggerPath, method, status, mediaType)
+	ex, _ := args.Get(0).(*Example)
+	return ex, args.Bool(1)
 }
 
 func (m *MockSpecProvider) FindOperation(swaggerPath, method string) *openapi3.Operation {
@@ -34,6 +40,12 @@ func (m *MockSpecProvider) FindOperation(swaggerPath, method string) *openapi3.O
 	return op
 }
 
+func (m *MockSpecProvider) FindPaging(swaggerPath, method string) *Paging {
+	args := m.Called(swaggerPath, method)
+	pg, _ := args.Get(0).(*Paging)
+	return pg
+}
+
 func (m *MockSpecProvider) GetSpec() *Spec {
 	args := m.Called()
 	op, _ := args.Get(0).(*Spec)
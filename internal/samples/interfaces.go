@@ -4,17 +4,19 @@
 
 package samples
 
+import "github.com/ozgen/openapi-emulator/internal/openapi"
+
 type ISampleProvider interface {
-	ResolveAndLoad(method, swaggerTpl, actualPath, legacyFlatFilename string) (*Response, error)
-	ResolvePath(method, swaggerTpl, actualPath, legacyFlatFilename string) (string, error)
+	ResolveAndLoad(method string, match *openapi.RouteMatch) (*Response, error)
+	ResolvePath(method string, match *openapi.RouteMatch) (string, error)
+	LoadStatusSample(method string, match *openapi.RouteMatch, status int) (*Response, error)
 }
 
 type IScenarioResolver interface {
 	ResolveScenarioFile(
 		sc *Scenario,
 		method string,
-		swaggerTpl string,
-		actualPath string,
+		match *openapi.RouteMatch,
 	) (file string, state string, err error)
-	TryResetByRequest(method, actualPath string) bool
+	TryResetByRequest(method string, match *openapi.RouteMatch) bool
 }
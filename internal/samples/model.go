@@ -12,10 +12,13 @@ type Envelope struct {
 	Body    any               `json:"body"`
 }
 
+// Response is a loaded sample. State is the scenario state it was chosen
+// for, empty outside of scenarios.
 type Response struct {
 	Status  int
 	Headers map[string]string
 	Body    []byte
+	State   string
 }
 
 type ProviderConfig struct {
@@ -11,12 +11,18 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/oasdiff/yaml"
+	"github.com/ozgen/openapi-emulator/internal/openapi"
 	"github.com/ozgen/openapi-emulator/utils"
 	"github.com/sirupsen/logrus"
 
 	"github.com/ozgen/openapi-emulator/config"
 )
 
+// sampleExtensions are the sample file extensions, in order of precedence
+// for files in the same place.
+var sampleExtensions = []string{".json", ".yaml", ".yml"}
+
 type SampleProvider struct {
 	cfg ProviderConfig
 	log *logrus.Logger
@@ -26,18 +32,31 @@ func NewSampleProvider(cfg ProviderConfig, log *logrus.Logger) ISampleProvider {
 	return &SampleProvider{cfg: cfg, log: log}
 }
 
-func (p *SampleProvider) ResolveAndLoad(method, swaggerTpl, actualPath, legacyFlatFilename string) (*Response, error) {
-	path, err := p.ResolvePath(method, swaggerTpl, actualPath, legacyFlatFilename)
+func (p *SampleProvider) ResolveAndLoad(method string, match *openapi.RouteMatch) (*Response, error) {
+	path, state, err := p.resolve(method, match)
 	if err != nil {
 		p.log.WithError(err).Info("failed to resolve path")
 		return nil, err
 	}
-	return loadFile(path)
+	resp, err := loadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	resp.State = state
+	return resp, nil
 }
 
-func (p *SampleProvider) ResolvePath(method, swaggerTpl, actualPath, legacyFlatFilename string) (string, error) {
+func (p *SampleProvider) ResolvePath(method string, match *openapi.RouteMatch) (string, error) {
+	path, _, err := p.resolve(method, match)
+	return path, err
+}
+
+// resolve returns the sample file for the request and, when a scenario
+// chose it, the scenario state.
+func (p *SampleProvider) resolve(method string, match *openapi.RouteMatch) (string, string, error) {
 	cfg := p.cfg
 	method = strings.ToUpper(method)
+	swaggerTpl := match.Route.Swagger
 
 	// Scenario priority
 	if cfg.ScenarioEnabled {
@@ -46,44 +65,73 @@ func (p *SampleProvider) ResolvePath(method, swaggerTpl, actualPath, legacyFlatF
 			sc, err := LoadScenario(scPath)
 			if err != nil {
 				p.log.WithError(err).Warn("failed to load scenario")
-				return "", fmt.Errorf("load scenario %s: %w", scPath, err)
+				return "", "", fmt.Errorf("load scenario %s: %w", scPath, err)
 			}
 			if cfg.ScenarioResolver == nil {
-				return "", fmt.Errorf("scenario enabled but engine is nil")
+				return "", "", fmt.Errorf("scenario enabled but engine is nil")
 			}
 
-			file, _, err := cfg.ScenarioResolver.ResolveScenarioFile(sc, method, swaggerTpl, actualPath)
+			file, state, err := cfg.ScenarioResolver.ResolveScenarioFile(sc, method, match)
 			if err != nil {
 				p.log.WithError(err).Warn("failed to resolve scenario")
-				return "", fmt.Errorf("scenario resolve: %w", err)
+				return "", "", fmt.Errorf("scenario resolve: %w", err)
 			}
 
 			full := filepath.Join(filepath.Dir(scPath), file)
 			if utils.FileExists(full) {
-				return full, nil
+				return full, state, nil
 			}
-			return "", fmt.Errorf("scenario file not found: %s", full)
+			return "", "", fmt.Errorf("scenario file not found: %s", full)
 		}
 		if cfg.ScenarioEnabled && cfg.ScenarioResolver != nil {
-			_ = cfg.ScenarioResolver.TryResetByRequest(method, actualPath)
+			_ = cfg.ScenarioResolver.TryResetByRequest(method, match)
 		}
 	}
 
 	// Non-scenario fallback: folder/flat
-	candidates := buildCandidates(cfg.Layout, method, swaggerTpl, legacyFlatFilename)
+	candidates := buildCandidates(cfg.Layout, method, swaggerTpl, match.Route.SampleFile)
 	if len(candidates) == 0 {
-		return "", fmt.Errorf("no candidates for method=%s path=%s", method, swaggerTpl)
+		return "", "", fmt.Errorf("no candidates for method=%s path=%s", method, swaggerTpl)
 	}
 
 	for _, rel := range candidates {
-		full := filepath.Join(cfg.BaseDir, rel)
-		if utils.FileExists(full) {
-			return full, nil
+		for _, name := range withExtensions(rel, "") {
+			full := filepath.Join(cfg.BaseDir, name)
+			if utils.FileExists(full) {
+				return full, "", nil
+			}
 		}
 	}
 
-	p.log.WithField("path", actualPath).Info("no sample found; caller may fallback to spec example")
-	return "", fmt.Errorf("no sample file found (tried: %v)", candidates)
+	p.log.WithFields(logrus.Fields{
+		"path":   match.Path,
+		"params": match.Params,
+	}).Info("no sample found; caller may fallback to spec example")
+	return "", "", fmt.Errorf("no sample file found (tried: %v)", candidates)
+}
+
+// LoadStatusSample loads the sample for a response with the given status,
+// e.g. <path>/GET.401.json, <path>/GET.401.yaml or GET__items.401.json.
+// Scenarios are not consulted.
+func (p *SampleProvider) LoadStatusSample(method string, match *openapi.RouteMatch, status int) (*Response, error) {
+	method = strings.ToUpper(method)
+	candidates := buildCandidates(p.cfg.Layout, method, match.Route.Swagger, match.Route.SampleFile)
+
+	for _, rel := range candidates {
+		for _, name := range withExtensions(rel, fmt.Sprintf(".%d", status)) {
+			full := filepath.Join(p.cfg.BaseDir, name)
+			if !utils.FileExists(full) {
+				continue
+			}
+			resp, err := loadFile(full)
+			if err != nil {
+				return nil, err
+			}
+			resp.Status = status
+			return resp, nil
+		}
+	}
+	return nil, fmt.Errorf("no %d sample file found for method=%s path=%s", status, method, match.Route.Swagger)
 }
 
 func buildCandidates(layout config.LayoutMode, method, swaggerPath, legacyFlatFilename string) []string {
@@ -103,11 +151,29 @@ func buildCandidates(layout config.LayoutMode, method, swaggerPath, legacyFlatFi
 	return out
 }
 
+// withExtensions returns the names of the sample file rel, a .json
+// candidate, with suffix and each of the sample extensions.
+func withExtensions(rel, suffix string) []string {
+	base := strings.TrimSuffix(rel, ".json") + suffix
+	out := make([]string, len(sampleExtensions))
+	for i, ext := range sampleExtensions {
+		out[i] = base + ext
+	}
+	return out
+}
+
 func loadFile(path string) (*Response, error) {
 	b, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("read sample %s: %w", path, err)
 	}
+	if ext := strings.ToLower(filepath.Ext(path)); ext == ".yaml" || ext == ".yml" {
+		if strings.TrimSpace(string(b)) != "" {
+			if b, err = yaml.YAMLToJSON(b); err != nil {
+				return nil, fmt.Errorf("parse yaml sample %s: %w", path, err)
+			}
+		}
+	}
 	raw := strings.TrimSpace(string(b))
 	if raw == "" {
 		return &Response{
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/ozgen/openapi-emulator/internal/openapi"
 	"github.com/ozgen/openapi-emulator/logger"
 
 	"github.com/ozgen/openapi-emulator/config"
@@ -23,10 +24,9 @@ type MockScenarioResolver struct {
 func (m *MockScenarioResolver) ResolveScenarioFile(
 	sc *Scenario,
 	method string,
-	swaggerTpl string,
-	actualPath string,
+	match *openapi.RouteMatch,
 ) (file string, state string, err error) {
-	args := m.Called(sc, method, swaggerTpl, actualPath)
+	args := m.Called(sc, method, match)
 
 	file, _ = args.Get(0).(string)
 	state, _ = args.Get(1).(string)
@@ -34,11 +34,17 @@ func (m *MockScenarioResolver) ResolveScenarioFile(
 	return
 }
 
-func (m *MockScenarioResolver) TryResetByRequest(method, actualPath string) bool {
-	args := m.Called(method, actualPath)
+func (m *MockScenarioResolver) TryResetByRequest(method string, match *openapi.RouteMatch) bool {
+	args := m.Called(method, match)
 	return args.Bool(0)
 }
 
+func sampleMatch(swaggerTpl, actualPath, legacyFlat string) *openapi.RouteMatch {
+	m := routeMatch(swaggerTpl, actualPath)
+	m.Route.SampleFile = legacyFlat
+	return m
+}
+
 func writeFile(t *testing.T, dir, name, content string) string {
 	t.Helper()
 	p := filepath.Join(dir, name)
@@ -194,7 +200,7 @@ func TestSampleProvider_ResolveAndLoad_FoldersMode_LoadsFolderSample(t *testing.
 		Layout:  config.LayoutFolders,
 	}, logger.GetLogger())
 
-	resp, err := p.ResolveAndLoad(method, swaggerTpl, actualPath, legacyFlat)
+	resp, err := p.ResolveAndLoad(method, sampleMatch(swaggerTpl, actualPath, legacyFlat))
 	require.NoError(t, err)
 
 	require.Equal(t, 200, resp.Status)
@@ -217,7 +223,7 @@ func TestSampleProvider_ResolveAndLoad_FlatMode_LoadsLegacyFlatSample(t *testing
 		Layout:  config.LayoutFlat,
 	}, logger.GetLogger())
 
-	resp, err := p.ResolveAndLoad(method, swaggerTpl, actualPath, legacyFlat)
+	resp, err := p.ResolveAndLoad(method, sampleMatch(swaggerTpl, actualPath, legacyFlat))
 	require.NoError(t, err)
 
 	require.Equal(t, `{"from":"flat"}`, string(resp.Body))
@@ -239,7 +245,7 @@ func TestSampleProvider_ResolveAndLoad_AutoMode_PrefersFoldersOverFlat(t *testin
 		Layout:  config.LayoutAuto,
 	}, logger.GetLogger())
 
-	resp, err := p.ResolveAndLoad(method, swaggerTpl, actualPath, legacyFlat)
+	resp, err := p.ResolveAndLoad(method, sampleMatch(swaggerTpl, actualPath, legacyFlat))
 	require.NoError(t, err)
 
 	require.Equal(t, `{"from":"folders"}`, string(resp.Body))
@@ -253,10 +259,108 @@ func TestSampleProvider_ResolvePath_MissingSample_ReturnsError(t *testing.T) {
 		Layout:  config.LayoutAuto,
 	}, logger.GetLogger())
 
-	_, err := p.ResolvePath("GET", "/api/v1/does-not-exist", "/api/v1/does-not-exist", "GET_api_v1_does_not_exist.json")
+	_, err := p.ResolvePath("GET", sampleMatch("/api/v1/does-not-exist", "/api/v1/does-not-exist", "GET_api_v1_does_not_exist.json"))
+	require.Error(t, err)
+}
+
+func TestSampleProvider_LoadStatusSample_FoldersAndFlat(t *testing.T) {
+	baseDir := t.TempDir()
+	legacyFlat := "GET_api_v1_items.json"
+
+	writeFile(t, baseDir, filepath.Join("api", "v1", "items", "GET.401.json"), `{"status":200,"body":{"from":"folders"}}`)
+	writeFile(t, baseDir, "GET_api_v1_items.403.json", `{"from":"flat"}`)
+
+	p := NewSampleProvider(ProviderConfig{
+		BaseDir: baseDir,
+		Layout:  config.LayoutAuto,
+	}, logger.GetLogger())
+	match := sampleMatch("/api/v1/items", "/api/v1/items", legacyFlat)
+
+	resp, err := p.LoadStatusSample("get", match, 401)
+	require.NoError(t, err)
+	require.Equal(t, 401, resp.Status)
+	require.Equal(t, `{"from":"folders"}`, string(resp.Body))
+
+	resp, err = p.LoadStatusSample("GET", match, 403)
+	require.NoError(t, err)
+	require.Equal(t, 403, resp.Status)
+	require.Equal(t, `{"from":"flat"}`, string(resp.Body))
+
+	_, err = p.LoadStatusSample("GET", match, 404)
 	require.Error(t, err)
 }
 
+func TestLoadFile_YAML_EnvelopeAndRawBody(t *testing.T) {
+	dir := t.TempDir()
+
+	p := writeFile(t, dir, "GET.yaml", `
+status: 201
+headers:
+  X-Test: "1"
+body:
+  id: abc
+  tags: [a, b]
+`)
+	resp, err := loadFile(p)
+	require.NoError(t, err)
+	require.Equal(t, 201, resp.Status)
+	require.Equal(t, "1", resp.Headers["X-Test"])
+	require.Equal(t, "application/json", resp.Headers["content-type"])
+	require.JSONEq(t, `{"id":"abc","tags":["a","b"]}`, string(resp.Body))
+
+	p = writeFile(t, dir, "GET.yml", "- id: 1\n- id: 2\n")
+	resp, err = loadFile(p)
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.Status)
+	require.JSONEq(t, `[{"id":1},{"id":2}]`, string(resp.Body))
+
+	p = writeFile(t, dir, "empty.yaml", "\n")
+	resp, err = loadFile(p)
+	require.NoError(t, err)
+	require.Equal(t, "{}", string(resp.Body))
+
+	p = writeFile(t, dir, "broken.yaml", "body: [unclosed")
+	_, err = loadFile(p)
+	require.Error(t, err)
+}
+
+func TestSampleProvider_ResolvePath_YAMLSamplesAndPrecedence(t *testing.T) {
+	baseDir := t.TempDir()
+	legacyFlat := "GET_api_v1_items.json"
+	match := sampleMatch("/api/v1/items", "/api/v1/items", legacyFlat)
+
+	p := NewSampleProvider(ProviderConfig{
+		BaseDir: baseDir,
+		Layout:  config.LayoutAuto,
+	}, logger.GetLogger())
+
+	flat := writeFile(t, baseDir, "GET_api_v1_items.yml", `body: {from: flat}`)
+	got, err := p.ResolvePath("GET", match)
+	require.NoError(t, err)
+	require.Equal(t, flat, got)
+
+	folderYML := writeFile(t, baseDir, filepath.Join("api", "v1", "items", "GET.yml"), `body: {from: yml}`)
+	got, err = p.ResolvePath("GET", match)
+	require.NoError(t, err)
+	require.Equal(t, folderYML, got)
+
+	folderYAML := writeFile(t, baseDir, filepath.Join("api", "v1", "items", "GET.yaml"), `body: {from: yaml}`)
+	got, err = p.ResolvePath("GET", match)
+	require.NoError(t, err)
+	require.Equal(t, folderYAML, got)
+
+	writeFile(t, baseDir, filepath.Join("api", "v1", "items", "GET.json"), `{"body":{"from":"json"}}`)
+	resp, err := p.ResolveAndLoad("GET", match)
+	require.NoError(t, err)
+	require.Equal(t, `{"from":"json"}`, string(resp.Body))
+
+	writeFile(t, baseDir, filepath.Join("api", "v1", "items", "GET.401.yaml"), `body: {error: unauthorized}`)
+	resp, err = p.LoadStatusSample("GET", match, 401)
+	require.NoError(t, err)
+	require.Equal(t, 401, resp.Status)
+	require.Equal(t, `{"error":"unauthorized"}`, string(resp.Body))
+}
+
 func TestSampleProvider_ScenarioEnabled_UsesScenarioEngine(t *testing.T) {
 	baseDir := t.TempDir()
 
@@ -280,7 +384,7 @@ func TestSampleProvider_ScenarioEnabled_UsesScenarioEngine(t *testing.T) {
 
 	m := new(MockScenarioResolver)
 
-	m.On("ResolveScenarioFile", mock.Anything, "GET", swaggerTpl, actualPath).
+	m.On("ResolveScenarioFile", mock.Anything, "GET", sampleMatch(swaggerTpl, actualPath, legacyFlat)).
 		Return("GET.requested.json", "requested", nil).
 		Once()
 
@@ -294,7 +398,7 @@ func TestSampleProvider_ScenarioEnabled_UsesScenarioEngine(t *testing.T) {
 		ScenarioResolver: m,
 	}, logger.GetLogger())
 
-	resp, err := p.ResolveAndLoad(method, swaggerTpl, actualPath, legacyFlat)
+	resp, err := p.ResolveAndLoad(method, sampleMatch(swaggerTpl, actualPath, legacyFlat))
 	require.NoError(t, err)
 	require.Equal(t, `{"from":"scenario"}`, string(resp.Body))
 
@@ -327,7 +431,7 @@ func TestSampleProvider_ScenarioEnabled_EngineNil_ReturnsError(t *testing.T) {
 		ScenarioResolver: nil,
 	}, logger.GetLogger())
 
-	_, err := p.ResolvePath(method, swaggerTpl, actualPath, "legacy.json")
+	_, err := p.ResolvePath(method, sampleMatch(swaggerTpl, actualPath, "legacy.json"))
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "engine is nil")
 }
@@ -351,7 +455,7 @@ func TestSampleProvider_ScenarioEnabled_FileReturnedButMissing_ReturnsError(t *t
 	}`)
 
 	m := new(MockScenarioResolver)
-	m.On("ResolveScenarioFile", mock.Anything, "GET", swaggerTpl, actualPath).
+	m.On("ResolveScenarioFile", mock.Anything, "GET", sampleMatch(swaggerTpl, actualPath, "legacy.json")).
 		Return("GET.requested.json", "requested", nil).
 		Once()
 
@@ -363,7 +467,7 @@ func TestSampleProvider_ScenarioEnabled_FileReturnedButMissing_ReturnsError(t *t
 		ScenarioResolver: m,
 	}, logger.GetLogger())
 
-	_, err := p.ResolvePath(method, swaggerTpl, actualPath, "legacy.json")
+	_, err := p.ResolvePath(method, sampleMatch(swaggerTpl, actualPath, "legacy.json"))
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "scenario file not found")
 
@@ -380,7 +484,7 @@ func TestSampleProvider_ScenarioEnabled_NoScenarioFile_CallsTryResetByRequest(t
 	legacyFlat := "DELETE__scans_{id}.json"
 
 	m := new(MockScenarioResolver)
-	m.On("TryResetByRequest", "DELETE", actualPath).Return(true).Once()
+	m.On("TryResetByRequest", "DELETE", sampleMatch(swaggerTpl, actualPath, legacyFlat)).Return(true).Once()
 
 	p := NewSampleProvider(ProviderConfig{
 		BaseDir:          baseDir,
@@ -390,7 +494,7 @@ func TestSampleProvider_ScenarioEnabled_NoScenarioFile_CallsTryResetByRequest(t
 		ScenarioResolver: m,
 	}, logger.GetLogger())
 
-	_, err := p.ResolvePath(method, swaggerTpl, actualPath, legacyFlat)
+	_, err := p.ResolvePath(method, sampleMatch(swaggerTpl, actualPath, legacyFlat))
 	require.Error(t, err)
 	m.AssertExpectations(t)
 }
@@ -404,7 +508,7 @@ func TestSampleProvider_ScenarioEnabled_NoScenarioFile_TryResetFalse_StillCalled
 	legacyFlat := "DELETE__scans_{id}.json"
 
 	m := new(MockScenarioResolver)
-	m.On("TryResetByRequest", "DELETE", actualPath).Return(false).Once()
+	m.On("TryResetByRequest", "DELETE", sampleMatch(swaggerTpl, actualPath, legacyFlat)).Return(false).Once()
 
 	p := NewSampleProvider(ProviderConfig{
 		BaseDir:          baseDir,
@@ -414,7 +518,7 @@ func TestSampleProvider_ScenarioEnabled_NoScenarioFile_TryResetFalse_StillCalled
 		ScenarioResolver: m,
 	}, logger.GetLogger())
 
-	_, err := p.ResolvePath(method, swaggerTpl, actualPath, legacyFlat)
+	_, err := p.ResolvePath(method, sampleMatch(swaggerTpl, actualPath, legacyFlat))
 	require.Error(t, err)
 	m.AssertExpectations(t)
 }
@@ -441,7 +545,7 @@ func TestSampleProvider_ScenarioEnabled_ScenarioFileExists_DoesNotCallTryResetBy
 	writeFile(t, filepath.Dir(scPath), "GET.requested.json", `{"body":{"ok":true}}`)
 
 	m := new(MockScenarioResolver)
-	m.On("ResolveScenarioFile", mock.Anything, "GET", swaggerTpl, actualPath).
+	m.On("ResolveScenarioFile", mock.Anything, "GET", sampleMatch(swaggerTpl, actualPath, legacyFlat)).
 		Return("GET.requested.json", "requested", nil).
 		Once()
 
@@ -453,7 +557,7 @@ func TestSampleProvider_ScenarioEnabled_ScenarioFileExists_DoesNotCallTryResetBy
 		ScenarioResolver: m,
 	}, logger.GetLogger())
 
-	_, err := p.ResolveAndLoad(method, swaggerTpl, actualPath, legacyFlat)
+	_, err := p.ResolveAndLoad(method, sampleMatch(swaggerTpl, actualPath, legacyFlat))
 	require.NoError(t, err)
 
 	m.AssertNotCalled(t, "TryResetByRequest", mock.Anything, mock.Anything)
@@ -13,6 +13,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ozgen/openapi-emulator/internal/openapi"
 	"github.com/ozgen/openapi-emulator/logger"
 	"github.com/sirupsen/logrus"
 )
@@ -100,21 +101,22 @@ func LoadScenario(scenarioPath string) (*Scenario, error) {
 func (e *ScenarioResolver) ResolveScenarioFile(
 	sc *Scenario,
 	method string,
-	swaggerTpl string,
-	actualPath string,
+	match *openapi.RouteMatch,
 ) (file string, state string, err error) {
 	method = strings.ToUpper(method)
+	swaggerTpl := match.Route.Swagger
 
-	keyVal, ok := extractPathParam(swaggerTpl, actualPath, sc.Key.PathParam)
+	keyVal, ok := match.Params[sc.Key.PathParam]
 	if !ok || strings.TrimSpace(keyVal) == "" {
 		e.log.WithFields(logrus.Fields{
 			"swaggerTpl": swaggerTpl,
-			"actualPath": actualPath,
+			"actualPath": match.Path,
+			"params":     match.Params,
 			"want":       sc.Key.PathParam,
 		}).Error("failed to extract key path param")
 		return "", "", fmt.Errorf(
 			"cannot extract key path param %q from path %q using template %q",
-			sc.Key.PathParam, actualPath, swaggerTpl,
+			sc.Key.PathParam, match.Path, swaggerTpl,
 		)
 	}
 
@@ -165,13 +167,13 @@ func (e *ScenarioResolver) ResolveScenarioFile(
 	case "step":
 		return e.resolveStep(k, sc, method)
 	case "time":
-		return e.resolveTime(k, sc, method, actualPath)
+		return e.resolveTime(k, sc, method, match.Path)
 	default:
 		return "", "", fmt.Errorf("unsupported mode %q", sc.Mode)
 	}
 }
 
-func (e *ScenarioResolver) TryResetByRequest(method, actualPath string) bool {
+func (e *ScenarioResolver) TryResetByRequest(method string, match *openapi.RouteMatch) bool {
 	method = strings.ToUpper(method)
 
 	e.mu.Lock()
@@ -188,11 +190,11 @@ func (e *ScenarioResolver) TryResetByRequest(method, actualPath string) bool {
 		rr := it.rule
 		b := it.binding
 
-		if rr.PathTpl != "" && !matchTemplatePathSuffix(rr.PathTpl, actualPath) {
+		if rr.PathTpl != "" && !matchTemplatePathSuffix(rr.PathTpl, match.Path) {
 			continue
 		}
 
-		keyVal, ok := extractPathParam(rr.PathTpl, actualPath, b.KeyParam)
+		keyVal, ok := match.Params[b.KeyParam]
 		if !ok || strings.TrimSpace(keyVal) == "" {
 			continue
 		}
@@ -337,24 +339,6 @@ func matchTemplatePathSuffix(tpl, actual string) bool {
 	return true
 }
 
-func extractPathParam(swaggerTpl, actualPath, want string) (string, bool) {
-	tplParts := strings.Split(strings.Trim(swaggerTpl, "/"), "/")
-	actParts := strings.Split(strings.Trim(actualPath, "/"), "/")
-	if len(tplParts) != len(actParts) {
-		return "", false
-	}
-	for i := range tplParts {
-		p := tplParts[i]
-		if strings.HasPrefix(p, "{") && strings.HasSuffix(p, "}") {
-			name := strings.TrimSuffix(strings.TrimPrefix(p, "{"), "}")
-			if name == want {
-				return actParts[i], true
-			}
-		}
-	}
-	return "", false
-}
-
 func ScenarioPathForSwagger(baseDir, swaggerPath, filename string) string {
 	pathDir := strings.TrimPrefix(swaggerPath, "/")
 	pathDir = filepath.FromSlash(pathDir)
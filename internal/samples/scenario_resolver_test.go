@@ -10,6 +10,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/ozgen/openapi-emulator/internal/openapi"
 )
 
 func TestScenarioPathForSwagger(t *testing.T) {
@@ -21,23 +23,6 @@ func TestScenarioPathForSwagger(t *testing.T) {
 	}
 }
 
-func TestExtractPathParam(t *testing.T) {
-	val, ok := extractPathParam("/api/v1/items/{id}", "/api/v1/items/777", "id")
-	if !ok || val != "777" {
-		t.Fatalf("expected ok=true val=777, got ok=%v val=%q", ok, val)
-	}
-
-	_, ok = extractPathParam("/api/v1/items/{id}", "/api/v1/items/777", "other")
-	if ok {
-		t.Fatalf("expected ok=false for missing param")
-	}
-
-	_, ok = extractPathParam("/api/v1/items/{id}", "/api/v1/items", "id")
-	if ok {
-		t.Fatalf("expected ok=false for different segment count")
-	}
-}
-
 func TestLoadScenario_ValidV1_Step(t *testing.T) {
 	dir := t.TempDir()
 	p := filepath.Join(dir, "scenario.json")
@@ -135,7 +120,7 @@ func TestScenarioResolver_ResolveScenarioFile_Step_SelectsFirstThenAdvances(t *t
 	sc.Behavior.AdvanceOn = []MatchRule{{Method: "GET"}}
 	sc.Behavior.RepeatLast = true
 
-	file1, state1, err := e.ResolveScenarioFile(sc, "get", "/api/v1/items/{id}", "/api/v1/items/1")
+	file1, state1, err := e.ResolveScenarioFile(sc, "get", routeMatch("/api/v1/items/{id}", "/api/v1/items/1"))
 	if err != nil {
 		t.Fatalf("ResolveScenarioFile: %v", err)
 	}
@@ -143,7 +128,7 @@ func TestScenarioResolver_ResolveScenarioFile_Step_SelectsFirstThenAdvances(t *t
 		t.Fatalf("expected a.json/requested got %q/%q", file1, state1)
 	}
 
-	file2, state2, err := e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/1")
+	file2, state2, err := e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/1"))
 	if err != nil {
 		t.Fatalf("ResolveScenarioFile: %v", err)
 	}
@@ -151,7 +136,7 @@ func TestScenarioResolver_ResolveScenarioFile_Step_SelectsFirstThenAdvances(t *t
 		t.Fatalf("expected b.json/running got %q/%q", file2, state2)
 	}
 
-	file3, state3, err := e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/1")
+	file3, state3, err := e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/1"))
 	if err != nil {
 		t.Fatalf("ResolveScenarioFile: %v", err)
 	}
@@ -159,7 +144,7 @@ func TestScenarioResolver_ResolveScenarioFile_Step_SelectsFirstThenAdvances(t *t
 		t.Fatalf("expected c.json/done got %q/%q", file3, state3)
 	}
 
-	file4, state4, err := e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/1")
+	file4, state4, err := e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/1"))
 	if err != nil {
 		t.Fatalf("ResolveScenarioFile: %v", err)
 	}
@@ -181,11 +166,11 @@ func TestScenarioResolver_ResolveScenarioFile_Step_NoAdvanceRule_StaysOnFirst(t
 	sc.Behavior.AdvanceOn = nil
 	sc.Behavior.RepeatLast = true
 
-	file1, _, err := e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/9")
+	file1, _, err := e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/9"))
 	if err != nil {
 		t.Fatalf("ResolveScenarioFile: %v", err)
 	}
-	file2, _, err := e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/9")
+	file2, _, err := e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/9"))
 	if err != nil {
 		t.Fatalf("ResolveScenarioFile: %v", err)
 	}
@@ -201,7 +186,7 @@ func TestScenarioResolver_ResolveScenarioFile_Step_RequiresNonEmptySequence(t *t
 	sc.Key.PathParam = "id"
 	sc.Sequence = nil
 
-	_, _, err := e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/1")
+	_, _, err := e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/1"))
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -218,7 +203,7 @@ func TestScenarioResolver_ResolveScenarioFile_Time_ChoosesBasedOnElapsed(t *test
 	}
 	sc.Behavior.RepeatLast = true
 
-	file1, state1, err := e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/5")
+	file1, state1, err := e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/5"))
 	if err != nil {
 		t.Fatalf("ResolveScenarioFile: %v", err)
 	}
@@ -228,7 +213,7 @@ func TestScenarioResolver_ResolveScenarioFile_Time_ChoosesBasedOnElapsed(t *test
 
 	time.Sleep(1100 * time.Millisecond)
 
-	file2, state2, err := e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/5")
+	file2, state2, err := e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/5"))
 	if err != nil {
 		t.Fatalf("ResolveScenarioFile: %v", err)
 	}
@@ -244,7 +229,7 @@ func TestScenarioResolver_ResolveScenarioFile_Time_RequiresNonEmptyTimeline(t *t
 	sc.Key.PathParam = "id"
 	sc.Timeline = nil
 
-	_, _, err := e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/1")
+	_, _, err := e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/1"))
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -263,18 +248,18 @@ func TestScenarioResolver_ResetOn_ClearsState_ViaTryResetByRequest(t *testing.T)
 	sc.Behavior.ResetOn = []MatchRule{{Method: "POST", Path: "/api/v1/items/{id}"}}
 	sc.Behavior.RepeatLast = true
 
-	_, _, _ = e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/1")
-	f2, _, _ := e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/1")
+	_, _, _ = e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/1"))
+	f2, _, _ := e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/1"))
 	if f2 != "b.json" {
 		t.Fatalf("expected b.json after advancing, got %q", f2)
 	}
 
-	reset := e.TryResetByRequest("POST", "/api/v1/items/1")
+	reset := e.TryResetByRequest("POST", routeMatch("/api/v1/items/{id}", "/api/v1/items/1"))
 	if !reset {
 		t.Fatalf("expected reset=true")
 	}
 
-	fAfter, _, err := e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/1")
+	fAfter, _, err := e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/1"))
 	if err != nil {
 		t.Fatalf("ResolveScenarioFile(after reset): %v", err)
 	}
@@ -291,7 +276,7 @@ func TestScenarioResolver_ResolveScenarioFile_KeyExtractionError(t *testing.T) {
 	sc.Sequence = []ScenarioEntry{{State: "s1", File: "a.json"}}
 	sc.Behavior.RepeatLast = true
 
-	_, _, err := e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items")
+	_, _, err := e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items"))
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -310,9 +295,9 @@ func TestScenarioResolver_ResolveScenarioFile_Step_Loop_WrapsToFirst(t *testing.
 	sc.Behavior.Loop = true
 	sc.Behavior.RepeatLast = true
 
-	f1, _, _ := e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/1")
-	f2, _, _ := e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/1")
-	f3, _, _ := e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/1")
+	f1, _, _ := e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/1"))
+	f2, _, _ := e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/1"))
+	f3, _, _ := e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/1"))
 
 	if f1 != "a.json" || f2 != "b.json" || f3 != "a.json" {
 		t.Fatalf("expected a,b,a got %q,%q,%q", f1, f2, f3)
@@ -331,10 +316,10 @@ func TestScenarioResolver_StateIsolation_ByID(t *testing.T) {
 	sc.Behavior.AdvanceOn = []MatchRule{{Method: "GET"}}
 	sc.Behavior.RepeatLast = true
 
-	_, _, _ = e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/1")
-	f1b, _, _ := e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/1")
+	_, _, _ = e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/1"))
+	f1b, _, _ := e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/1"))
 
-	f2a, _, _ := e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/2")
+	f2a, _, _ := e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/2"))
 
 	if f1b != "b.json" {
 		t.Fatalf("expected id=1 to be b.json, got %q", f1b)
@@ -356,16 +341,16 @@ func TestScenarioResolver_Time_RepeatLast_SticksToLast(t *testing.T) {
 	sc.Behavior.RepeatLast = true
 	sc.Behavior.Loop = false
 
-	_, _, _ = e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/5")
+	_, _, _ = e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/5"))
 	time.Sleep(1100 * time.Millisecond)
 
-	f2, s2, _ := e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/5")
+	f2, s2, _ := e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/5"))
 	if f2 != "t1.json" || s2 != "t1" {
 		t.Fatalf("expected t1.json/t1 got %q/%q", f2, s2)
 	}
 
 	time.Sleep(1200 * time.Millisecond)
-	f3, s3, _ := e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/5")
+	f3, s3, _ := e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/5"))
 	if f3 != "t1.json" || s3 != "t1" {
 		t.Fatalf("expected sticky t1.json/t1 got %q/%q", f3, s3)
 	}
@@ -445,17 +430,17 @@ func TestScenarioResolver_Step_WhenPastEnd_ClampsToLastEvenWithoutRepeatLast(t *
 	sc.Behavior.RepeatLast = false
 	sc.Behavior.Loop = false
 
-	f1, _, err := e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/1")
+	f1, _, err := e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/1"))
 	if err != nil {
 		t.Fatalf("unexpected err: %v", err)
 	}
 
-	f2, _, err := e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/1")
+	f2, _, err := e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/1"))
 	if err != nil {
 		t.Fatalf("unexpected err: %v", err)
 	}
 
-	f3, _, err := e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/1")
+	f3, _, err := e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/1"))
 	if err != nil {
 		t.Fatalf("unexpected err: %v", err)
 	}
@@ -477,8 +462,8 @@ func TestScenarioResolver_Step_AdvanceOn_MethodMatchIsCaseInsensitive(t *testing
 	sc.Behavior.AdvanceOn = []MatchRule{{Method: "get"}} // lowercase
 	sc.Behavior.RepeatLast = true
 
-	f1, _, _ := e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/1")
-	f2, _, _ := e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/1")
+	f1, _, _ := e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/1"))
+	f2, _, _ := e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/1"))
 
 	if f1 != "a.json" || f2 != "b.json" {
 		t.Fatalf("expected a then b, got %q then %q", f1, f2)
@@ -498,18 +483,18 @@ func TestScenarioResolver_ResetOn_PathMismatch_DoesNotReset(t *testing.T) {
 	sc.Behavior.ResetOn = []MatchRule{{Method: "POST", Path: "/api/v1/other/{id}"}}
 	sc.Behavior.RepeatLast = true
 
-	_, _, _ = e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/1")
-	f2, _, _ := e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/1")
+	_, _, _ = e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/1"))
+	f2, _, _ := e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/1"))
 	if f2 != "b.json" {
 		t.Fatalf("expected b.json after advancing, got %q", f2)
 	}
 
-	_, _, err := e.ResolveScenarioFile(sc, "POST", "/api/v1/items/{id}", "/api/v1/items/1")
+	_, _, err := e.ResolveScenarioFile(sc, "POST", routeMatch("/api/v1/items/{id}", "/api/v1/items/1"))
 	if err != nil {
 		t.Fatalf("unexpected err: %v", err)
 	}
 
-	fAfter, _, _ := e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/1")
+	fAfter, _, _ := e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/1"))
 	if fAfter != "b.json" {
 		t.Fatalf("expected still b.json (no reset), got %q", fAfter)
 	}
@@ -527,7 +512,7 @@ func TestScenarioResolver_Time_Loop_Wraps(t *testing.T) {
 	sc.Behavior.Loop = true
 	sc.Behavior.RepeatLast = false
 
-	f1, s1, err := e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/5")
+	f1, s1, err := e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/5"))
 	if err != nil {
 		t.Fatalf("unexpected err: %v", err)
 	}
@@ -536,13 +521,13 @@ func TestScenarioResolver_Time_Loop_Wraps(t *testing.T) {
 	}
 
 	time.Sleep(1100 * time.Millisecond)
-	f2, s2, _ := e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/5")
+	f2, s2, _ := e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/5"))
 	if f2 != "t1.json" || s2 != "t1" {
 		t.Fatalf("expected t1 after ~1s, got %q/%q", f2, s2)
 	}
 
 	time.Sleep(1200 * time.Millisecond)
-	f3, s3, _ := e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/5")
+	f3, s3, _ := e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/5"))
 	if f3 != "t0.json" || s3 != "t0" {
 		t.Fatalf("expected wrap to t0, got %q/%q", f3, s3)
 	}
@@ -559,15 +544,15 @@ func TestScenarioResolver_Time_StateIsolation_ByID(t *testing.T) {
 	}
 	sc.Behavior.RepeatLast = true
 
-	_, _, _ = e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/1")
+	_, _, _ = e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/1"))
 	time.Sleep(1100 * time.Millisecond)
 
-	f1, _, _ := e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/1")
+	f1, _, _ := e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/1"))
 	if f1 != "t1.json" {
 		t.Fatalf("expected id=1 to be t1.json, got %q", f1)
 	}
 
-	f2, _, _ := e.ResolveScenarioFile(sc, "GET", "/api/v1/items/{id}", "/api/v1/items/2")
+	f2, _, _ := e.ResolveScenarioFile(sc, "GET", routeMatch("/api/v1/items/{id}", "/api/v1/items/2"))
 	if f2 != "t0.json" {
 		t.Fatalf("expected id=2 to start at t0.json, got %q", f2)
 	}
@@ -609,7 +594,7 @@ func TestScenarioResolver_RegistersResetRules_OnFirstResolve(t *testing.T) {
 		{Method: "DELETE", Path: "/scans/{id}"},
 	}
 
-	_, _, err := e.ResolveScenarioFile(sc, "GET", "/scans/{id}", "/scans/1")
+	_, _, err := e.ResolveScenarioFile(sc, "GET", routeMatch("/scans/{id}", "/scans/1"))
 	if err != nil {
 		t.Fatalf("unexpected err: %v", err)
 	}
@@ -641,18 +626,18 @@ func TestScenarioResolver_TryResetByRequest_ResetsRunningScenario(t *testing.T)
 	sc.Behavior.ResetOn = []MatchRule{{Method: "DELETE", Path: "/scans/{id}"}}
 	sc.Behavior.RepeatLast = true
 
-	_, _, _ = e.ResolveScenarioFile(sc, "GET", "/scans/{id}", "/scans/1")
-	f2, _, _ := e.ResolveScenarioFile(sc, "GET", "/scans/{id}", "/scans/1")
+	_, _, _ = e.ResolveScenarioFile(sc, "GET", routeMatch("/scans/{id}", "/scans/1"))
+	f2, _, _ := e.ResolveScenarioFile(sc, "GET", routeMatch("/scans/{id}", "/scans/1"))
 	if f2 != "b.json" {
 		t.Fatalf("expected b.json after advancing, got %q", f2)
 	}
 
-	reset := e.TryResetByRequest("DELETE", "/scans/1")
+	reset := e.TryResetByRequest("DELETE", routeMatch("/scans/{id}", "/scans/1"))
 	if !reset {
 		t.Fatalf("expected reset=true")
 	}
 
-	fAfter, _, _ := e.ResolveScenarioFile(sc, "GET", "/scans/{id}", "/scans/1")
+	fAfter, _, _ := e.ResolveScenarioFile(sc, "GET", routeMatch("/scans/{id}", "/scans/1"))
 	if fAfter != "a.json" {
 		t.Fatalf("expected a.json after reset, got %q", fAfter)
 	}
@@ -671,15 +656,15 @@ func TestScenarioResolver_TryResetByRequest_WrongMethod_DoesNotReset(t *testing.
 	sc.Behavior.ResetOn = []MatchRule{{Method: "DELETE", Path: "/scans/{id}"}}
 	sc.Behavior.RepeatLast = true
 
-	_, _, _ = e.ResolveScenarioFile(sc, "GET", "/scans/{id}", "/scans/1")
-	_, _, _ = e.ResolveScenarioFile(sc, "GET", "/scans/{id}", "/scans/1") // now at b
+	_, _, _ = e.ResolveScenarioFile(sc, "GET", routeMatch("/scans/{id}", "/scans/1"))
+	_, _, _ = e.ResolveScenarioFile(sc, "GET", routeMatch("/scans/{id}", "/scans/1")) // now at b
 
-	reset := e.TryResetByRequest("POST", "/scans/1")
+	reset := e.TryResetByRequest("POST", routeMatch("/scans/{id}", "/scans/1"))
 	if reset {
 		t.Fatalf("expected reset=false")
 	}
 
-	fAfter, _, _ := e.ResolveScenarioFile(sc, "GET", "/scans/{id}", "/scans/1")
+	fAfter, _, _ := e.ResolveScenarioFile(sc, "GET", routeMatch("/scans/{id}", "/scans/1"))
 	if fAfter != "b.json" {
 		t.Fatalf("expected still b.json (no reset), got %q", fAfter)
 	}
@@ -698,15 +683,15 @@ func TestScenarioResolver_TryResetByRequest_PathMismatch_DoesNotReset(t *testing
 	sc.Behavior.ResetOn = []MatchRule{{Method: "DELETE", Path: "/scans/{id}"}}
 	sc.Behavior.RepeatLast = true
 
-	_, _, _ = e.ResolveScenarioFile(sc, "GET", "/scans/{id}", "/scans/1")
-	_, _, _ = e.ResolveScenarioFile(sc, "GET", "/scans/{id}", "/scans/1") // now at b
+	_, _, _ = e.ResolveScenarioFile(sc, "GET", routeMatch("/scans/{id}", "/scans/1"))
+	_, _, _ = e.ResolveScenarioFile(sc, "GET", routeMatch("/scans/{id}", "/scans/1")) // now at b
 
-	reset := e.TryResetByRequest("DELETE", "/other/1")
+	reset := e.TryResetByRequest("DELETE", routeMatch("/other/{id}", "/other/1"))
 	if reset {
 		t.Fatalf("expected reset=false")
 	}
 
-	fAfter, _, _ := e.ResolveScenarioFile(sc, "GET", "/scans/{id}", "/scans/1")
+	fAfter, _, _ := e.ResolveScenarioFile(sc, "GET", routeMatch("/scans/{id}", "/scans/1"))
 	if fAfter != "b.json" {
 		t.Fatalf("expected still b.json (no reset), got %q", fAfter)
 	}
@@ -726,23 +711,42 @@ func TestScenarioResolver_TryResetByRequest_ResetsScenarioRegisteredFromDifferen
 	sc.Behavior.ResetOn = []MatchRule{{Method: "DELETE", Path: "/scans/{id}"}}
 	sc.Behavior.RepeatLast = true
 
-	_, _, _ = e.ResolveScenarioFile(sc, "GET", "/scans/{id}/status", "/scans/1/status")
-	f2, _, _ := e.ResolveScenarioFile(sc, "GET", "/scans/{id}/status", "/scans/1/status")
+	_, _, _ = e.ResolveScenarioFile(sc, "GET", routeMatch("/scans/{id}/status", "/scans/1/status"))
+	f2, _, _ := e.ResolveScenarioFile(sc, "GET", routeMatch("/scans/{id}/status", "/scans/1/status"))
 	if f2 != "b.json" {
 		t.Fatalf("expected b.json after advancing, got %q", f2)
 	}
 
-	reset := e.TryResetByRequest("DELETE", "/scans/1")
+	reset := e.TryResetByRequest("DELETE", routeMatch("/scans/{id}", "/scans/1"))
 	if !reset {
 		t.Fatalf("expected reset=true")
 	}
 
-	fAfter, _, _ := e.ResolveScenarioFile(sc, "GET", "/scans/{id}/status", "/scans/1/status")
+	fAfter, _, _ := e.ResolveScenarioFile(sc, "GET", routeMatch("/scans/{id}/status", "/scans/1/status"))
 	if fAfter != "a.json" {
 		t.Fatalf("expected a.json after reset, got %q", fAfter)
 	}
 }
 
+// routeMatch builds the match the router would return for path.
+func routeMatch(swaggerTpl, path string) *openapi.RouteMatch {
+	params := map[string]string{}
+	tplParts := strings.Split(strings.Trim(swaggerTpl, "/"), "/")
+	actParts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(tplParts) == len(actParts) {
+		for i, p := range tplParts {
+			if strings.HasPrefix(p, "{") && strings.HasSuffix(p, "}") {
+				params[strings.Trim(p, "{}")] = actParts[i]
+			}
+		}
+	}
+	return &openapi.RouteMatch{
+		Route:  &openapi.Route{Swagger: swaggerTpl},
+		Path:   path,
+		Params: params,
+	}
+}
+
 func writeF(t *testing.T, path string, content string) {
 	t.Helper()
 	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
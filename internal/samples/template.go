@@ -0,0 +1,217 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package samples
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	mathrand "math/rand/v2"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/ozgen/openapi-emulator/internal/openapi"
+)
+
+// TemplateData is what sample templates see of the request:
+// {{.Path.id}}, {{.Query.limit}}, {{index .Headers "X-Request-Id"}},
+// {{.Body.name}} for a JSON request body and {{.State}}, the scenario
+// state the sample was chosen for.
+type TemplateData struct {
+	Method  string
+	Path    map[string]string
+	Query   map[string]string
+	Headers map[string]string
+	Body    any
+	State   string
+}
+
+var templateFuncs = template.FuncMap{
+	"now":       templateNow,
+	"uuid":      templateUUID,
+	"randomInt": templateRandomInt,
+	"jsonPath":  jsonPath,
+	"json":      templateJSON,
+}
+
+// Render executes the templates in the headers and body of r against
+// data. Each string of a JSON body is a template of its own, so actions
+// may use quotes; any other body is a single template. A JSON body must
+// still be valid JSON after rendering.
+func (r *Response) Render(data *TemplateData) error {
+	for _, k := range sortedHeaderNames(r.Headers) {
+		v, err := execTemplate("header "+k, r.Headers[k], data)
+		if err != nil {
+			return err
+		}
+		r.Headers[k] = v
+	}
+
+	if !bytes.Contains(r.Body, []byte("{{")) {
+		return nil
+	}
+	ct, _ := headerGet(r.Headers, "content-type")
+	isJSON := ct == "" || openapi.IsJSONMediaType(ct)
+
+	if isJSON && json.Valid(r.Body) {
+		dec := json.NewDecoder(bytes.NewReader(r.Body))
+		dec.UseNumber()
+		var v any
+		if err := dec.Decode(&v); err != nil {
+			return fmt.Errorf("decode sample body: %w", err)
+		}
+		v, err := renderValue(v, "body", data)
+		if err != nil {
+			return err
+		}
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(v); err != nil {
+			return fmt.Errorf("encode sample body: %w", err)
+		}
+		r.Body = bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+		return nil
+	}
+
+	body, err := execTemplate("body", string(r.Body), data)
+	if err != nil {
+		return err
+	}
+	if isJSON && !json.Valid([]byte(body)) {
+		return fmt.Errorf("template body: result is not valid JSON: %s", body)
+	}
+	r.Body = []byte(body)
+	return nil
+}
+
+// renderValue renders the strings and object keys of a decoded JSON value.
+// name locates v in the body, e.g. body.items[0].id, for error messages.
+func renderValue(v any, name string, data *TemplateData) (any, error) {
+	switch t := v.(type) {
+	case string:
+		return execTemplate(name, t, data)
+	case []any:
+		for i, item := range t {
+			rendered, err := renderValue(item, fmt.Sprintf("%s[%d]", name, i), data)
+			if err != nil {
+				return nil, err
+			}
+			t[i] = rendered
+		}
+		return t, nil
+	case map[string]any:
+		out := make(map[string]any, len(t))
+		for k, item := range t {
+			key, err := execTemplate(name+" key "+strconv.Quote(k), k, data)
+			if err != nil {
+				return nil, err
+			}
+			if out[key], err = renderValue(item, name+"."+k, data); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	default:
+		return v, nil
+	}
+}
+
+// execTemplate renders text as the template name. Text without actions is
+// returned as it is.
+func execTemplate(name, text string, data *TemplateData) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+	tpl, err := template.New(name).Funcs(templateFuncs).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("parse template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("execute template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+func sortedHeaderNames(h map[string]string) []string {
+	names := make([]string, 0, len(h))
+	for k := range h {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// templateNow returns the current UTC time in layout, RFC 3339 by default.
+func templateNow(layout ...string) string {
+	l := time.RFC3339
+	if len(layout) > 0 {
+		l = layout[0]
+	}
+	return time.Now().UTC().Format(l)
+}
+
+// templateUUID returns a random version 4 UUID.
+func templateUUID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+// templateRandomInt returns a random number between min and max, both
+// included.
+func templateRandomInt(lo, hi int) (int, error) {
+	if hi < lo {
+		return 0, fmt.Errorf("randomInt: max %d is less than min %d", hi, lo)
+	}
+	return lo + mathrand.IntN(hi-lo+1), nil
+}
+
+func templateJSON(v any) (string, error) {
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+// jsonPath returns the value at path in v, a decoded JSON value. Paths
+// look like $.items[0].id; the leading "$." is optional. Missing values
+// are empty.
+func jsonPath(v any, path string) any {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	path = strings.ReplaceAll(path, "[", ".[")
+	for _, part := range strings.Split(path, ".") {
+		switch {
+		case part == "":
+			continue
+		case strings.HasPrefix(part, "[") && strings.HasSuffix(part, "]"):
+			items, ok := v.([]any)
+			i, err := strconv.Atoi(part[1 : len(part)-1])
+			if !ok || err != nil || i < 0 || i >= len(items) {
+				return ""
+			}
+			v = items[i]
+		default:
+			m, ok := v.(map[string]any)
+			if !ok {
+				return ""
+			}
+			if v, ok = m[part]; !ok {
+				return ""
+			}
+		}
+	}
+	if v == nil {
+		return ""
+	}
+	return v
+}
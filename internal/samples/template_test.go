@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package samples
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func templateTestData() *TemplateData {
+	return &TemplateData{
+		Method:  "POST",
+		Path:    map[string]string{"id": "42"},
+		Query:   map[string]string{"verbose": "true"},
+		Headers: map[string]string{"X-Request-Id": "req-1"},
+		Body:    map[string]any{"name": "scan", "targets": []any{map[string]any{"host": "10.0.0.1"}}, "count": json.Number("3")},
+		State:   "running",
+	}
+}
+
+func TestResponseRender_JSONStringsAndHeaders(t *testing.T) {
+	resp := &Response{
+		Status: 200,
+		Headers: map[string]string{
+			"content-type": "application/json",
+			"X-Request-Id": `{{index .Headers "X-Request-Id"}}`,
+			"Location":     "/items/{{.Path.id}}",
+		},
+		Body: []byte(`{
+			"id": "{{.Path.id}}",
+			"name": "{{.Body.name}} <{{.Method}}>",
+			"host": "{{jsonPath .Body \"$.targets[0].host\"}}",
+			"count": "{{.Body.count}}",
+			"state": "{{.State}}",
+			"verbose": "{{.Query.verbose}}",
+			"missing": "{{.Query.nope}}{{jsonPath .Body \"targets[3]\"}}",
+			"{{.Path.id}}": [1, "{{.State}}"],
+			"fixed": 7
+		}`),
+	}
+	require.NoError(t, resp.Render(templateTestData()))
+
+	require.Equal(t, "req-1", resp.Headers["X-Request-Id"])
+	require.Equal(t, "/items/42", resp.Headers["Location"])
+	require.JSONEq(t, `{
+		"id": "42",
+		"name": "scan <POST>",
+		"host": "10.0.0.1",
+		"count": "3",
+		"state": "running",
+		"verbose": "true",
+		"missing": "",
+		"42": [1, "running"],
+		"fixed": 7
+	}`, string(resp.Body))
+	require.Contains(t, string(resp.Body), "<POST>")
+}
+
+func TestResponseRender_RawTemplateBody(t *testing.T) {
+	resp := &Response{
+		Headers: map[string]string{"content-type": "application/json"},
+		Body:    []byte(`{"id": {{.Path.id}}, "size": {{randomInt 5 5}}, "body": {{json .Body.targets}}}`),
+	}
+	require.NoError(t, resp.Render(templateTestData()))
+	require.JSONEq(t, `{"id": 42, "size": 5, "body": [{"host": "10.0.0.1"}]}`, string(resp.Body))
+
+	resp = &Response{
+		Headers: map[string]string{"content-type": "text/plain"},
+		Body:    []byte(`scan {{.Path.id}} is {{.State}}`),
+	}
+	require.NoError(t, resp.Render(templateTestData()))
+	require.Equal(t, "scan 42 is running", string(resp.Body))
+}
+
+func TestResponseRender_Helpers(t *testing.T) {
+	resp := &Response{
+		Headers: map[string]string{"content-type": "application/json"},
+		Body:    []byte(`{"id": "{{uuid}}", "at": "{{now}}", "day": "{{now \"2006-01-02\"}}"}`),
+	}
+	require.NoError(t, resp.Render(templateTestData()))
+
+	var got map[string]string
+	require.NoError(t, json.Unmarshal(resp.Body, &got))
+	require.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), got["id"])
+	require.Regexp(t, regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z$`), got["at"])
+	require.Regexp(t, regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`), got["day"])
+}
+
+func TestResponseRender_Errors(t *testing.T) {
+	cases := map[string]*Response{
+		"body.items[0]": {
+			Headers: map[string]string{"content-type": "application/json"},
+			Body:    []byte(`{"items": ["{{.Path.id"]}`),
+		},
+		"not valid JSON": {
+			Headers: map[string]string{"content-type": "application/json"},
+			Body:    []byte(`{"id": {{.Query.nope}}}`),
+		},
+		"randomInt": {
+			Body: []byte(`{"n": "{{randomInt 5 1}}"}`),
+		},
+		"header Location": {
+			Headers: map[string]string{"Location": "{{.Nope}}"},
+		},
+	}
+	for want, resp := range cases {
+		err := resp.Render(templateTestData())
+		require.Error(t, err, want)
+		require.True(t, strings.Contains(err.Error(), want), "expected %q in %v", want, err)
+	}
+}
+
+func TestResponseRender_NoTemplatesKeepsBody(t *testing.T) {
+	body := `{"b": 1,   "a": "<x>"}`
+	resp := &Response{Headers: map[string]string{}, Body: []byte(body)}
+	require.NoError(t, resp.Render(templateTestData()))
+	require.Equal(t, body, string(resp.Body))
+}
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ozgen/openapi-emulator/config"
+	"github.com/ozgen/openapi-emulator/internal/openapi"
+)
+
+// Problem is an error response generated by the emulator itself rather
+// than taken from a sample.
+type Problem struct {
+	Status int
+	Title  string
+	Detail string
+	// Extensions are additional members such as method, path or violations.
+	Extensions map[string]any
+
+	// Match and Spec are set once a route matched. Renderers may use them
+	// to shape the body after the operation's declared responses.
+	Match *openapi.RouteMatch
+	Spec  openapi.ISpecProvider
+}
+
+// IErrorRenderer writes a Problem to the client.
+type IErrorRenderer interface {
+	Render(w http.ResponseWriter, r *http.Request, p *Problem)
+}
+
+// NewErrorRenderer returns the built-in renderer for format.
+func NewErrorRenderer(format config.ErrorFormat) IErrorRenderer {
+	if format == config.ErrorFormatSpec {
+		return &specErrorRenderer{fallback: problemRenderer{}}
+	}
+	return problemRenderer{}
+}
+
+// problemRenderer writes RFC 7807 application/problem+json documents.
+// Extensions become top-level members next to the standard ones.
+type problemRenderer struct{}
+
+func (problemRenderer) Render(w http.ResponseWriter, r *http.Request, p *Problem) {
+	body := make(map[string]any, len(p.Extensions)+5)
+	for k, v := range p.Extensions {
+		body[k] = v
+	}
+	body["type"] = "about:blank"
+	body["title"] = p.Title
+	body["status"] = p.Status
+	body["instance"] = r.URL.Path
+	if p.Detail != "" {
+		body["detail"] = p.Detail
+	}
+
+	b, _ := json.Marshal(body)
+	w.Header().Set("content-type", "application/problem+json")
+	w.WriteHeader(p.Status)
+	_, _ = w.Write(b)
+}
+
+// specErrorRenderer answers with the example (or schema-generated body),
+// media type and headers of the response the operation declares for the
+// status, so emulator errors look like upstream errors. Without a
+// matched operation or a declared response it falls back to an RFC 7807
+// application/problem+json document.
+type specErrorRenderer struct {
+	fallback IErrorRenderer
+}
+
+func (sr *specErrorRenderer) Render(w http.ResponseWriter, r *http.Request, p *Problem) {
+	if p.Match != nil && p.Spec != nil {
+		rt := p.Match.Route
+		if ex, ok := p.Spec.TryGetStatusExample(rt.Swagger, rt.Method, p.Status, ""); ok {
+			writeExample(w, ex, p.Status)
+			return
+		}
+	}
+	sr.fallback.Render(w, r, p)
+}
+
+// problem builds a Problem for an error on the matched route of snap.
+func (snap *snapshot) problem(match *openapi.RouteMatch, status int, title, detail string, ext map[string]any) *Problem {
+	return &Problem{
+		Status:     status,
+		Title:      title,
+		Detail:     detail,
+		Extensions: ext,
+		Match:      match,
+		Spec:       snap.specProvider,
+	}
+}
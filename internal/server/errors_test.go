@@ -0,0 +1,229 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ozgen/openapi-emulator/config"
+)
+
+func TestProblemRenderer_StandardAndExtensionMembers(t *testing.T) {
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/items/1?x=1", nil)
+
+	NewErrorRenderer(config.ErrorFormatProblem).Render(rr, req, &Problem{
+		Status:     501,
+		Title:      "No sample file for route",
+		Detail:     "missing",
+		Extensions: map[string]any{"swaggerPath": "/items/{id}", "status": 200},
+	})
+
+	if rr.Code != 501 {
+		t.Fatalf("expected 501, got %d", rr.Code)
+	}
+	if ct := rr.Header().Get("content-type"); ct != "application/problem+json" {
+		t.Fatalf("unexpected content-type %q", ct)
+	}
+	var m map[string]any
+	if err := json.Unmarshal(rr.Body.Bytes(), &m); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	want := map[string]any{
+		"type":        "about:blank",
+		"title":       "No sample file for route",
+		"status":      float64(501),
+		"detail":      "missing",
+		"instance":    "/items/1",
+		"swaggerPath": "/items/{id}",
+	}
+	for k, v := range want {
+		if m[k] != v {
+			t.Fatalf("%s: expected %v, got %v", k, v, m[k])
+		}
+	}
+}
+
+func TestProblemRenderer_OmitsEmptyDetail(t *testing.T) {
+	rr := httptest.NewRecorder()
+	NewErrorRenderer("").Render(rr, httptest.NewRequest(http.MethodGet, "/x", nil), &Problem{Status: 404, Title: "No route"})
+
+	if strings.Contains(rr.Body.String(), `"detail"`) {
+		t.Fatalf("expected no detail member, got %s", rr.Body.String())
+	}
+}
+
+func newErrorFormatServer(t *testing.T, format config.ErrorFormat, renderer IErrorRenderer) *Server {
+	t.Helper()
+	disableScenarioForTests()
+
+	dir := t.TempDir()
+	spec := strings.Replace(minimalSpec(), `"responses":{
+			  "200":{`, `"responses":{
+			  "default":{"description":"error","content":{"application/json":{"example":{"code":"E_UPSTREAM"}}}},
+			  "200":{`, 1)
+	specPath := writeFile(t, dir, "spec.json", spec)
+	writeFileWithDirs(t, dir, filepath.Join("items", "POST.json"), `{"status":201,"body":{"created":true}}`)
+
+	s, err := New(Config{
+		Port:           "0",
+		SpecPath:       specPath,
+		SamplesDir:     dir,
+		FallbackMode:   config.FallbackNone,
+		ValidationMode: config.ValidationRequired,
+		Layout:         config.LayoutFolders,
+		ErrorFormat:    format,
+		ErrorRenderer:  renderer,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return s
+}
+
+func TestHandle_ErrorFormatSpec_UsesDeclaredErrorResponse(t *testing.T) {
+	s := newErrorFormatServer(t, config.ErrorFormatSpec, nil)
+
+	// GET /items/{id} declares a default response; no sample exists
+	rr := httptest.NewRecorder()
+	s.handle(rr, httptest.NewRequest(http.MethodGet, "http://example.com/items/1", nil))
+	if rr.Code != 501 {
+		t.Fatalf("expected 501, got %d", rr.Code)
+	}
+	if ct := rr.Header().Get("content-type"); ct != "application/json" {
+		t.Fatalf("unexpected content-type %q", ct)
+	}
+	if strings.TrimSpace(rr.Body.String()) != `{"code":"E_UPSTREAM"}` {
+		t.Fatalf("unexpected body: %s", rr.Body.String())
+	}
+
+	// POST /items declares no error response: problem+json
+	rr = httptest.NewRecorder()
+	s.handle(rr, httptest.NewRequest(http.MethodPost, "http://example.com/items", nil))
+	if rr.Code != 400 || rr.Header().Get("content-type") != "application/problem+json" {
+		t.Fatalf("expected problem+json 400, got %d %q", rr.Code, rr.Header().Get("content-type"))
+	}
+
+	// no route matched: problem+json
+	rr = httptest.NewRecorder()
+	s.handle(rr, httptest.NewRequest(http.MethodGet, "http://example.com/nope", nil))
+	if rr.Code != 404 || rr.Header().Get("content-type") != "application/problem+json" {
+		t.Fatalf("expected problem+json 404, got %d %q", rr.Code, rr.Header().Get("content-type"))
+	}
+}
+
+func TestHandle_ErrorFormatSpec_UsesDeclaredMediaTypeAndHeaders(t *testing.T) {
+	disableScenarioForTests()
+
+	dir := t.TempDir()
+	spec := strings.Replace(minimalSpec(), `"responses":{
+			  "200":{`, `"responses":{
+			  "default":{
+				"description":"error",
+				"headers":{"X-Request-Id":{"schema":{"type":"string"},"example":"req-1"}},
+				"content":{"application/problem+json":{"example":{"title":"upstream"}}}
+			  },
+			  "200":{`, 1)
+	specPath := writeFile(t, dir, "spec.json", spec)
+
+	s, err := New(Config{
+		Port:           "0",
+		SpecPath:       specPath,
+		SamplesDir:     dir,
+		FallbackMode:   config.FallbackNone,
+		ValidationMode: config.ValidationRequired,
+		Layout:         config.LayoutFolders,
+		ErrorFormat:    config.ErrorFormatSpec,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	rr := httptest.NewRecorder()
+	s.handle(rr, httptest.NewRequest(http.MethodGet, "http://example.com/items/1", nil))
+	if rr.Code != 501 || strings.TrimSpace(rr.Body.String()) != `{"title":"upstream"}` {
+		t.Fatalf("unexpected response %d %s", rr.Code, rr.Body.String())
+	}
+	if ct := rr.Header().Get("content-type"); ct != "application/problem+json" {
+		t.Fatalf("expected the declared media type, got %q", ct)
+	}
+	if got := rr.Header().Get("X-Request-Id"); got != "req-1" {
+		t.Fatalf("expected the declared header, got %q", got)
+	}
+}
+
+func TestHandle_ErrorFormatSpec_Swagger2Examples(t *testing.T) {
+	disableScenarioForTests()
+
+	dir := t.TempDir()
+	specPath := writeFile(t, dir, "swagger.json", `{
+	  "swagger":"2.0",
+	  "info":{"title":"t","version":"1"},
+	  "paths":{
+	    "/items/{id}":{"get":{
+	      "parameters":[{"name":"id","in":"path","required":true,"type":"string"}],
+	      "responses":{
+	        "200":{"description":"ok","schema":{"type":"object"}},
+	        "default":{
+	          "description":"error",
+	          "examples":{"application/problem+json":{"title":"upstream"}}
+	        }
+	      }
+	    }}
+	  }
+	}`)
+
+	s, err := New(Config{
+		Port:           "0",
+		SpecPath:       specPath,
+		SamplesDir:     dir,
+		FallbackMode:   config.FallbackNone,
+		ValidationMode: config.ValidationRequired,
+		Layout:         config.LayoutFolders,
+		ErrorFormat:    config.ErrorFormatSpec,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	rr := httptest.NewRecorder()
+	s.handle(rr, httptest.NewRequest(http.MethodGet, "http://example.com/items/1", nil))
+	if rr.Code != 501 || strings.TrimSpace(rr.Body.String()) != `{"title":"upstream"}` {
+		t.Fatalf("expected the 2.0 example, got %d %s", rr.Code, rr.Body.String())
+	}
+	if ct := rr.Header().Get("content-type"); ct != "application/problem+json" {
+		t.Fatalf("expected the media type of the 2.0 example, got %q", ct)
+	}
+}
+
+type recordingRenderer struct {
+	problems []*Problem
+}
+
+func (rr *recordingRenderer) Render(w http.ResponseWriter, r *http.Request, p *Problem) {
+	rr.problems = append(rr.problems, p)
+	w.WriteHeader(p.Status)
+}
+
+func TestHandle_CustomErrorRenderer(t *testing.T) {
+	renderer := &recordingRenderer{}
+	s := newErrorFormatServer(t, config.ErrorFormatSpec, renderer)
+
+	rr := httptest.NewRecorder()
+	s.handle(rr, httptest.NewRequest(http.MethodGet, "http://example.com/items/1", nil))
+
+	if rr.Code != 501 || len(renderer.problems) != 1 {
+		t.Fatalf("expected custom renderer to be used, got %d %v", rr.Code, renderer.problems)
+	}
+	p := renderer.problems[0]
+	if p.Match == nil || p.Match.Route.Swagger != "/items/{id}" || p.Spec == nil {
+		t.Fatalf("expected match and spec on problem, got %#v", p)
+	}
+}
@@ -0,0 +1,194 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package server
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/ozgen/openapi-emulator/config"
+	"github.com/ozgen/openapi-emulator/internal/openapi"
+	"github.com/ozgen/openapi-emulator/internal/samples"
+	"github.com/sirupsen/logrus"
+)
+
+// mount serves one spec and its samples under a URL prefix.
+// Each mount keeps its own scenario state.
+type mount struct {
+	prefix     string
+	specPath   string
+	samplesDir string
+	cfg        Config
+	log        *logrus.Logger
+
+	snap atomic.Pointer[snapshot]
+}
+
+// snapshot is everything built from the spec and samples on disk. Requests
+// read one snapshot for their whole lifetime, so a reload never changes the
+// providers under an in-flight request.
+type snapshot struct {
+	specProvider   openapi.ISpecProvider
+	routerProvider openapi.IRouterProvider
+	validator      openapi.IValidator
+	sampleProvider samples.ISampleProvider
+	scenario       samples.IScenarioResolver
+	specReport     *openapi.SpecReport
+}
+
+func newMount(mc config.MountConfig, cfg Config, log *logrus.Logger) (*mount, error) {
+	if strings.TrimSpace(mc.SpecPath) == "" {
+		return nil, fmt.Errorf("mount %q: spec path is required", mc.Prefix)
+	}
+
+	m := &mount{
+		prefix:     normalizePrefix(mc.Prefix),
+		specPath:   mc.SpecPath,
+		samplesDir: mc.SamplesDir,
+		cfg:        cfg,
+		log:        log,
+	}
+
+	snap, err := m.load(nil)
+	if err != nil {
+		return nil, fmt.Errorf("mount %q: %w", mc.Prefix, err)
+	}
+	m.snap.Store(snap)
+
+	return m, nil
+}
+
+// load parses the spec and builds a new snapshot. A nil scenario resolver
+// starts with fresh scenario state.
+func (m *mount) load(scenario samples.IScenarioResolver) (*snapshot, error) {
+	specProvider, err := openapi.NewSpecProvider(m.specPath, m.log)
+	if err != nil {
+		return nil, err
+	}
+
+	sp, ok := specProvider.(*openapi.SpecProvider)
+	if !ok {
+		return nil, fmt.Errorf("unexpected spec provider type: %T", specProvider)
+	}
+
+	report := sp.ValidationReport()
+	if m.cfg.SpecValidation == config.SpecValidationStrict && !report.OK() {
+		return nil, fmt.Errorf("invalid spec %s: %s", m.specPath, report)
+	}
+
+	routerProvider := openapi.NewRouterProvider(sp.GetSpec(), openapi.RouterConfig{
+		BasePathMode: m.cfg.BasePathMode,
+	})
+
+	snap := &snapshot{
+		specProvider:   specProvider,
+		routerProvider: routerProvider,
+		validator:      openapi.NewValidator(specProvider),
+		specReport:     report,
+	}
+
+	providerCfg := samples.ProviderConfig{
+		BaseDir:          m.samplesDir,
+		Layout:           m.cfg.Layout,
+		ScenarioEnabled:  config.Envs.Scenario.Enabled,
+		ScenarioFilename: config.Envs.Scenario.Filename,
+	}
+
+	if config.Envs.Scenario.Enabled {
+		if scenario == nil {
+			scenario = samples.NewScenarioResolver()
+		}
+		snap.scenario = scenario
+		providerCfg.ScenarioResolver = scenario
+	}
+
+	snap.sampleProvider = samples.NewSampleProvider(providerCfg, m.log)
+
+	return snap, nil
+}
+
+func (m *mount) current() *snapshot {
+	return m.snap.Load()
+}
+
+// reload rebuilds the snapshot after the given files changed. Scenario state
+// survives unless a scenario file itself changed. On error the previous
+// snapshot stays active.
+func (m *mount) reload(changed []string) error {
+	var scenario samples.IScenarioResolver
+	if !touchesScenario(changed) {
+		scenario = m.current().scenario
+	}
+
+	snap, err := m.load(scenario)
+	if err != nil {
+		return err
+	}
+	m.snap.Store(snap)
+	return nil
+}
+
+func touchesScenario(changed []string) bool {
+	for _, p := range changed {
+		if filepath.Base(p) == config.Envs.Scenario.Filename {
+			return true
+		}
+	}
+	return false
+}
+
+// match reports whether path lies under the mount prefix and returns the
+// path relative to it.
+func (m *mount) match(path string) (string, bool) {
+	if m.prefix == "" {
+		return path, true
+	}
+	if path == m.prefix {
+		return "/", true
+	}
+	if strings.HasPrefix(path, m.prefix+"/") {
+		return strings.TrimPrefix(path, m.prefix), true
+	}
+	return "", false
+}
+
+func buildMounts(cfg Config, log *logrus.Logger) ([]*mount, error) {
+	mcs := cfg.Mounts
+	if len(mcs) == 0 {
+		mcs = []config.MountConfig{{SpecPath: cfg.SpecPath, SamplesDir: cfg.SamplesDir}}
+	}
+
+	seen := map[string]bool{}
+	var out []*mount
+	for _, mc := range mcs {
+		m, err := newMount(mc, cfg, log)
+		if err != nil {
+			return nil, err
+		}
+		if seen[m.prefix] {
+			return nil, fmt.Errorf("duplicate mount prefix %q", mc.Prefix)
+		}
+		seen[m.prefix] = true
+		out = append(out, m)
+	}
+
+	// longest prefix first, so "/a/b" wins over "/a" and "" comes last
+	sort.SliceStable(out, func(i, j int) bool {
+		return len(out[i].prefix) > len(out[j].prefix)
+	})
+
+	return out, nil
+}
+
+func normalizePrefix(prefix string) string {
+	prefix = strings.Trim(strings.TrimSpace(prefix), "/")
+	if prefix == "" {
+		return ""
+	}
+	return "/" + prefix
+}
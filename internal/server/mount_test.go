@@ -0,0 +1,243 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ozgen/openapi-emulator/config"
+	"github.com/sirupsen/logrus"
+)
+
+func TestNormalizePrefix(t *testing.T) {
+	cases := map[string]string{
+		"":           "",
+		"/":          "",
+		"openvasd":   "/openvasd",
+		"/openvasd/": "/openvasd",
+		" /a/b ":     "/a/b",
+	}
+	for in, want := range cases {
+		if got := normalizePrefix(in); got != want {
+			t.Fatalf("normalizePrefix(%q): got %q want %q", in, got, want)
+		}
+	}
+}
+
+func TestMount_Match(t *testing.T) {
+	m := &mount{prefix: "/openvasd"}
+
+	cases := []struct {
+		path string
+		rel  string
+		ok   bool
+	}{
+		{"/openvasd/scans", "/scans", true},
+		{"/openvasd", "/", true},
+		{"/openvasd2/scans", "", false},
+		{"/scans", "", false},
+	}
+	for _, tc := range cases {
+		rel, ok := m.match(tc.path)
+		if ok != tc.ok || rel != tc.rel {
+			t.Fatalf("match(%q): got (%q,%v) want (%q,%v)", tc.path, rel, ok, tc.rel, tc.ok)
+		}
+	}
+
+	root := &mount{}
+	if rel, ok := root.match("/scans"); !ok || rel != "/scans" {
+		t.Fatalf("root mount should match everything, got (%q,%v)", rel, ok)
+	}
+}
+
+func TestBuildMounts_LongestPrefixFirst(t *testing.T) {
+	disableScenarioForTests()
+
+	dir := t.TempDir()
+	spec := writeFile(t, dir, "spec.json", minimalSpec())
+
+	mounts, err := buildMounts(Config{Mounts: []config.MountConfig{
+		{Prefix: "/", SpecPath: spec, SamplesDir: dir},
+		{Prefix: "/a", SpecPath: spec, SamplesDir: dir},
+		{Prefix: "/a/b", SpecPath: spec, SamplesDir: dir},
+	}}, logrus.New())
+	if err != nil {
+		t.Fatalf("buildMounts: %v", err)
+	}
+
+	got := []string{mounts[0].prefix, mounts[1].prefix, mounts[2].prefix}
+	want := []string{"/a/b", "/a", ""}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("unexpected order: %v", got)
+		}
+	}
+}
+
+func TestBuildMounts_Errors(t *testing.T) {
+	disableScenarioForTests()
+
+	dir := t.TempDir()
+	spec := writeFile(t, dir, "spec.json", minimalSpec())
+
+	if _, err := buildMounts(Config{Mounts: []config.MountConfig{
+		{Prefix: "/a", SpecPath: spec, SamplesDir: dir},
+		{Prefix: "/a/", SpecPath: spec, SamplesDir: dir},
+	}}, logrus.New()); err == nil {
+		t.Fatalf("expected duplicate prefix error")
+	}
+
+	if _, err := buildMounts(Config{Mounts: []config.MountConfig{
+		{Prefix: "/a"},
+	}}, logrus.New()); err == nil {
+		t.Fatalf("expected missing spec path error")
+	}
+}
+
+func TestBuildMounts_ScenarioStatePerMount(t *testing.T) {
+	config.Envs.Scenario.Enabled = true
+	t.Cleanup(disableScenarioForTests)
+
+	dir := t.TempDir()
+	spec := writeFile(t, dir, "spec.json", minimalSpec())
+
+	mounts, err := buildMounts(Config{Mounts: []config.MountConfig{
+		{Prefix: "/a", SpecPath: spec, SamplesDir: dir},
+		{Prefix: "/b", SpecPath: spec, SamplesDir: dir},
+	}}, logrus.New())
+	if err != nil {
+		t.Fatalf("buildMounts: %v", err)
+	}
+	a, b := mounts[0].current(), mounts[1].current()
+	if a.scenario == nil || b.scenario == nil {
+		t.Fatalf("expected scenario resolvers")
+	}
+	if a.scenario == b.scenario {
+		t.Fatalf("expected separate scenario state per mount")
+	}
+}
+
+func TestMount_Reload_SwapsSnapshot(t *testing.T) {
+	disableScenarioForTests()
+
+	dir := t.TempDir()
+	spec := writeFile(t, dir, "spec.json", minimalSpec())
+
+	m, err := newMount(config.MountConfig{SpecPath: spec, SamplesDir: dir}, Config{Layout: config.LayoutFolders}, logrus.New())
+	if err != nil {
+		t.Fatalf("newMount: %v", err)
+	}
+	before := m.current()
+
+	writeFile(t, dir, "spec.json", strings.Replace(minimalSpec(), `"/items":{`, `"/things":{`, 1))
+	if err := m.reload([]string{spec}); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+
+	after := m.current()
+	if after == before {
+		t.Fatalf("expected new snapshot")
+	}
+	if m, _ := after.routerProvider.FindRoute("POST", "/things"); m == nil {
+		t.Fatalf("expected reloaded route /things")
+	}
+	if m, _ := before.routerProvider.FindRoute("POST", "/items"); m == nil {
+		t.Fatalf("old snapshot must stay usable for in-flight requests")
+	}
+}
+
+func TestMount_Reload_ErrorKeepsPreviousSnapshot(t *testing.T) {
+	disableScenarioForTests()
+
+	dir := t.TempDir()
+	spec := writeFile(t, dir, "spec.json", minimalSpec())
+
+	m, err := newMount(config.MountConfig{SpecPath: spec, SamplesDir: dir}, Config{}, logrus.New())
+	if err != nil {
+		t.Fatalf("newMount: %v", err)
+	}
+	before := m.current()
+
+	writeFile(t, dir, "spec.json", `{ not json`)
+	if err := m.reload([]string{spec}); err == nil {
+		t.Fatalf("expected reload error")
+	}
+	if m.current() != before {
+		t.Fatalf("expected previous snapshot to stay active")
+	}
+}
+
+func TestMount_Reload_ScenarioStateKeptUnlessScenarioChanged(t *testing.T) {
+	config.Envs.Scenario.Enabled = true
+	config.Envs.Scenario.Filename = "scenario.json"
+	t.Cleanup(disableScenarioForTests)
+
+	dir := t.TempDir()
+	spec := writeFile(t, dir, "spec.json", minimalSpec())
+
+	m, err := newMount(config.MountConfig{SpecPath: spec, SamplesDir: dir}, Config{}, logrus.New())
+	if err != nil {
+		t.Fatalf("newMount: %v", err)
+	}
+	sc := m.current().scenario
+
+	if err := m.reload([]string{filepath.Join(dir, "items", "GET.json")}); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if m.current().scenario != sc {
+		t.Fatalf("expected scenario state to survive a sample change")
+	}
+
+	if err := m.reload([]string{filepath.Join(dir, "items", "scenario.json")}); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if m.current().scenario == sc {
+		t.Fatalf("expected fresh scenario state after scenario change")
+	}
+}
+
+func TestServer_ReloadChanged_ServesNewSample(t *testing.T) {
+	s := newTestServer(t, config.ValidationNone, config.FallbackNone)
+	m := s.mounts[0]
+	watchers := []*fileWatcher{newFileWatcher(m.specPath, m.samplesDir)}
+
+	writeFile(t, m.samplesDir, "spec.json", strings.Replace(minimalSpec(), `"/items":{`, `"/things":{`, 1))
+	writeFileWithDirs(t, m.samplesDir, filepath.Join("things", "POST.json"), `{"reloaded":true}`)
+	s.reloadChanged(watchers)
+
+	rr := httptest.NewRecorder()
+	s.handle(rr, httptest.NewRequest(http.MethodPost, "http://example.com/things", strings.NewReader(`{}`)))
+	if rr.Code != 200 || strings.TrimSpace(rr.Body.String()) != `{"reloaded":true}` {
+		t.Fatalf("unexpected body after reload: %q", rr.Body.String())
+	}
+}
+
+func TestNewMount_SpecValidation(t *testing.T) {
+	disableScenarioForTests()
+
+	dir := t.TempDir()
+	// minimalSpec does not declare the {id} path parameter
+	spec := writeFile(t, dir, "spec.json", minimalSpec())
+
+	m, err := newMount(config.MountConfig{SpecPath: spec, SamplesDir: dir}, Config{SpecValidation: config.SpecValidationLenient}, logrus.New())
+	if err != nil {
+		t.Fatalf("lenient mode must not fail: %v", err)
+	}
+	if m.current().specReport.OK() {
+		t.Fatalf("expected lenient report to list issues")
+	}
+
+	_, err = newMount(config.MountConfig{SpecPath: spec, SamplesDir: dir}, Config{SpecValidation: config.SpecValidationStrict}, logrus.New())
+	if err == nil {
+		t.Fatalf("expected strict mode to refuse invalid spec")
+	}
+	if !strings.Contains(err.Error(), "/paths/~1items~1{id}/get/parameters") {
+		t.Fatalf("expected JSON pointer in error, got %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/ozgen/openapi-emulator/internal/openapi"
+)
+
+// paging returns the paging params declared on the route, or nil when
+// pagination is disabled or the route declares none.
+func (s *Server) paging(snap *snapshot, rt *openapi.Route) *openapi.Paging {
+	if !s.cfg.Pagination.Enabled {
+		return nil
+	}
+	return snap.specProvider.FindPaging(rt.Swagger, rt.Method)
+}
+
+// paginate slices the list of a success body to the page r asks for and
+// sets the paging headers. Other bodies are returned as they are.
+func paginate(pg *openapi.Paging, r *http.Request, status int, body []byte, headers map[string]string) ([]byte, map[string]string) {
+	if status != 0 && (status < 200 || status > 299) {
+		return body, headers
+	}
+	paged, extra, ok := pg.Paginate(body, r.URL)
+	if !ok {
+		return body, headers
+	}
+
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	for k := range headers {
+		if strings.EqualFold(k, "content-length") {
+			delete(headers, k)
+		}
+		for name := range extra {
+			if strings.EqualFold(k, name) {
+				delete(headers, k)
+			}
+		}
+	}
+	for name, v := range extra {
+		headers[name] = v
+	}
+	return paged, headers
+}
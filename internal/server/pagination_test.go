@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/ozgen/openapi-emulator/config"
+)
+
+const pagedSpec = `{
+  "openapi":"3.0.3",
+  "info":{"title":"t","version":"1"},
+  "paths":{
+	"/scans":{
+	  "get":{
+		"parameters":[
+		  {"name":"limit","in":"query","schema":{"type":"integer","default":2}},
+		  {"name":"offset","in":"query","schema":{"type":"integer"}}
+		],
+		"responses":{
+		  "200":{
+			"description":"ok",
+			"headers":{"Link":{"schema":{"type":"string"}}},
+			"content":{"application/json":{"schema":{
+			  "type":"object",
+			  "properties":{
+				"items":{"type":"array","items":{"type":"object","properties":{"id":{"type":"string"}}}},
+				"total":{"type":"integer"},
+				"next":{"type":"string","nullable":true}
+			  }
+			}}}
+		  }
+		}
+	  }
+	}
+  }
+}`
+
+func TestHandle_Pagination_SamplesAndFallback(t *testing.T) {
+	disableScenarioForTests()
+
+	dir := t.TempDir()
+	specPath := writeFile(t, dir, "spec.json", pagedSpec)
+
+	newServer := func(samplesDir string, enabled bool) *Server {
+		s, err := New(Config{
+			Port:           "0",
+			SpecPath:       specPath,
+			SamplesDir:     samplesDir,
+			FallbackMode:   config.FallbackOpenAPIExample,
+			ValidationMode: config.ValidationRequired,
+			Layout:         config.LayoutFolders,
+			Pagination:     config.PaginationConfig{Enabled: enabled, Total: 7},
+		})
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+		return s
+	}
+	get := func(s *Server, target string) (*httptest.ResponseRecorder, map[string]any) {
+		rr := httptest.NewRecorder()
+		s.handle(rr, httptest.NewRequest(http.MethodGet, "http://example.com"+target, nil))
+		var m map[string]any
+		if err := json.Unmarshal(rr.Body.Bytes(), &m); err != nil {
+			t.Fatalf("decode %s: %v", rr.Body.String(), err)
+		}
+		return rr, m
+	}
+
+	samples := t.TempDir()
+	writeFileWithDirs(t, samples, filepath.Join("scans", "GET.json"), `{
+	  "status": 200,
+	  "headers": {"content-type":"application/json","content-length":"999"},
+	  "body": {"items":[{"id":"a"},{"id":"b"},{"id":"c"}],"total":0,"next":null}
+	}`)
+
+	rr, m := get(newServer(samples, true), "/scans?offset=1&limit=1")
+	if items := m["items"].([]any); len(items) != 1 || items[0].(map[string]any)["id"] != "b" {
+		t.Fatalf("expected the second sample item, got %v", m)
+	}
+	if m["total"] != float64(3) || m["next"] != "/scans?limit=1&offset=2" {
+		t.Fatalf("unexpected envelope %v", m)
+	}
+	if link := rr.Header().Get("Link"); link == "" || rr.Header().Get("content-length") == "999" {
+		t.Fatalf("unexpected headers %v", rr.Header())
+	}
+
+	_, m = get(newServer(t.TempDir(), true), "/scans?offset=6")
+	if items := m["items"].([]any); len(items) != 1 || m["total"] != float64(7) || m["next"] != nil {
+		t.Fatalf("expected the last page of 7 generated items, got %v", m)
+	}
+
+	_, m = get(newServer(samples, false), "/scans?offset=1&limit=1")
+	if items := m["items"].([]any); len(items) != 3 {
+		t.Fatalf("expected the sample untouched when disabled, got %v", m)
+	}
+}
@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/ozgen/openapi-emulator/config"
+	"github.com/ozgen/openapi-emulator/internal/openapi"
+)
+
+// preference holds the Prism-style preferences of a request, e.g.
+// "Prefer: code=404, example=scanNotFound".
+type preference struct {
+	code    int
+	example string
+}
+
+func (p preference) set() bool {
+	return p.code != 0 || p.example != ""
+}
+
+func (p preference) String() string {
+	var parts []string
+	if p.code != 0 {
+		parts = append(parts, "code="+strconv.Itoa(p.code))
+	}
+	if p.example != "" {
+		parts = append(parts, "example="+p.example)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// parsePrefer reads the code and example preferences from Prefer header
+// values. Preferences are separated by "," or ";"; unknown ones are ignored.
+func parsePrefer(values []string) preference {
+	var p preference
+	for _, v := range values {
+		for _, part := range strings.FieldsFunc(v, func(r rune) bool { return r == ',' || r == ';' }) {
+			key, val, _ := strings.Cut(strings.TrimSpace(part), "=")
+			val = strings.Trim(strings.TrimSpace(val), `"`)
+			switch strings.ToLower(strings.TrimSpace(key)) {
+			case "code":
+				if n, err := strconv.Atoi(val); err == nil && n >= 100 && n <= 599 {
+					p.code = n
+				}
+			case "example":
+				p.example = val
+			}
+		}
+	}
+	return p
+}
+
+// servePreferred answers a request with a Prefer header. A preferred code
+// is served from the status sample file if there is one, otherwise from
+// the response the spec declares for it; a preferred example always comes
+// from the spec. A code the request is answered with anyway takes the
+// regular path instead, see Server.handle.
+func (s *Server) servePreferred(w http.ResponseWriter, r *http.Request, snap *snapshot, match *openapi.RouteMatch, pref preference) {
+	rt := match.Route
+
+	mediaType, ok := s.negotiate(w, r, snap, match, pref.code, pref.example)
+	if !ok {
+		return
+	}
+
+	if pref.code != 0 && pref.example == "" {
+		if resp, err := snap.sampleProvider.LoadStatusSample(rt.Method, match, pref.code); err == nil {
+			if !s.renderSample(w, r, snap, match, resp) {
+				return
+			}
+			s.writeSample(w, r, snap, match, resp, mediaType)
+			return
+		}
+	}
+
+	if s.cfg.FallbackMode == config.FallbackOpenAPIExample {
+		opts := s.exampleOptions(r, rt.Method, mediaType)
+		opts.Status, opts.Name = pref.code, pref.example
+		if ex, ok := snap.specProvider.TryGetExample(rt.Swagger, rt.Method, opts); ok {
+			status := pref.code
+			if status == 0 {
+				status = ex.Status
+			}
+			writeExample(w, ex, status)
+			return
+		}
+	}
+
+	s.renderer.Render(w, r, snap.problem(match, 501, "No response for Prefer header",
+		fmt.Sprintf("neither a sample file nor the spec provides a response for %s", pref), map[string]any{
+			"method":      r.Method,
+			"path":        r.URL.Path,
+			"swaggerPath": rt.Swagger,
+			"prefer":      pref.String(),
+			"responses":   declaredResponses(snap.specProvider.FindOperation(rt.Swagger, rt.Method)),
+		}))
+}
+
+// declaredResponses lists the response codes of op and the names of their
+// examples, e.g. "404 (notFound, gone)".
+func declaredResponses(op *openapi3.Operation) []string {
+	if op == nil || op.Responses == nil {
+		return nil
+	}
+
+	var out []string
+	for code, ref := range op.Responses.Map() {
+		var names []string
+		if ref != nil && ref.Value != nil {
+			for _, mt := range ref.Value.Content {
+				for name := range mt.Examples {
+					if !slices.Contains(names, name) {
+						names = append(names, name)
+					}
+				}
+			}
+		}
+		sort.Strings(names)
+		if len(names) > 0 {
+			code += " (" + strings.Join(names, ", ") + ")"
+		}
+		out = append(out, code)
+	}
+	sort.Strings(out)
+	return out
+}
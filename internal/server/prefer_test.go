@@ -0,0 +1,188 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ozgen/openapi-emulator/config"
+)
+
+func TestParsePrefer(t *testing.T) {
+	cases := []struct {
+		in   []string
+		want preference
+	}{
+		{nil, preference{}},
+		{[]string{"code=404"}, preference{code: 404}},
+		{[]string{`code=404, example="notFound"`}, preference{code: 404, example: "notFound"}},
+		{[]string{"return=minimal; Code=503"}, preference{code: 503}},
+		{[]string{"code=99", "example=gone"}, preference{example: "gone"}},
+		{[]string{"code=abc"}, preference{}},
+	}
+	for _, tc := range cases {
+		if got := parsePrefer(tc.in); got != tc.want {
+			t.Fatalf("%q: expected %+v, got %+v", tc.in, tc.want, got)
+		}
+	}
+}
+
+func TestHandle_Prefer_CodeAndExample(t *testing.T) {
+	disableScenarioForTests()
+
+	dir := t.TempDir()
+	spec := strings.Replace(minimalSpec(), `"200":{
+				"description":"ok",
+				"content":{
+				  "application/json":{
+					"example":{"id":"example"}
+				  }
+				}
+			  }`, `"200":{
+				"description":"ok",
+				"content":{
+				  "application/json":{
+					"examples":{
+					  "running":{"value":{"status":"running"}},
+					  "finished":{"value":{"status":"finished"}}
+					}
+				  }
+				}
+			  },
+			  "404":{
+				"description":"not found",
+				"content":{
+				  "application/json":{
+					"examples":{"notFound":{"value":{"error":"not found"}}}
+				  }
+				}
+			  },
+			  "409":{
+				"description":"conflict",
+				"content":{
+				  "application/json":{"example":{"error":"conflict"}}
+				}
+			  }`, 1)
+	specPath := writeFile(t, dir, "spec.json", spec)
+	writeFileWithDirs(t, dir, filepath.Join("items", "{id}", "GET.json"), `{"status":200,"body":{"from":"sample"}}`)
+	writeFileWithDirs(t, dir, filepath.Join("items", "{id}", "GET.409.json"), `{"body":{"from":"status sample"}}`)
+
+	newServer := func(fallback config.FallbackMode) *Server {
+		s, err := New(Config{
+			Port:           "0",
+			SpecPath:       specPath,
+			SamplesDir:     dir,
+			FallbackMode:   fallback,
+			ValidationMode: config.ValidationRequired,
+			Layout:         config.LayoutFolders,
+		})
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+		return s
+	}
+	get := func(s *Server, prefer string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "http://example.com/items/1", nil)
+		if prefer != "" {
+			req.Header.Set("Prefer", prefer)
+		}
+		rr := httptest.NewRecorder()
+		s.handle(rr, req)
+		return rr
+	}
+
+	s := newServer(config.FallbackOpenAPIExample)
+	cases := []struct {
+		prefer string
+		status int
+		body   string
+	}{
+		{"", 200, `{"from":"sample"}`},
+		{"code=200", 200, `{"from":"sample"}`},
+		{"code=404", 404, `{"error":"not found"}`},
+		{"code=409", 409, `{"from":"status sample"}`},
+		{"example=running", 200, `{"status":"running"}`},
+		{"example=notFound", 404, `{"error":"not found"}`},
+		{"code=200, example=finished", 200, `{"status":"finished"}`},
+	}
+	for _, tc := range cases {
+		rr := get(s, tc.prefer)
+		if rr.Code != tc.status || strings.TrimSpace(rr.Body.String()) != tc.body {
+			t.Fatalf("Prefer %q: expected %d %s, got %d %s", tc.prefer, tc.status, tc.body, rr.Code, rr.Body.String())
+		}
+	}
+
+	for _, prefer := range []string{"code=500", "example=missing", "code=404, example=running"} {
+		rr := get(s, prefer)
+		if rr.Code != 501 {
+			t.Fatalf("Prefer %q: expected 501, got %d %s", prefer, rr.Code, rr.Body.String())
+		}
+		var m map[string]any
+		if err := json.Unmarshal(rr.Body.Bytes(), &m); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if m["prefer"] == "" || len(m["responses"].([]any)) != 3 {
+			t.Fatalf("Prefer %q: expected declared responses, got %v", prefer, m)
+		}
+	}
+
+	if rr := get(newServer(config.FallbackNone), "code=404"); rr.Code != 501 {
+		t.Fatalf("expected 501 without spec fallback, got %d", rr.Code)
+	}
+	if rr := get(newServer(config.FallbackNone), "code=409"); rr.Code != 409 {
+		t.Fatalf("expected the status sample without spec fallback, got %d", rr.Code)
+	}
+}
+
+func TestHandle_Prefer_CodeOfResolvedSample(t *testing.T) {
+	disableScenarioForTests()
+
+	dir := t.TempDir()
+	spec := strings.Replace(minimalSpec(), `"200":{`, `"404":{
+				"description":"not found",
+				"content":{"application/json":{"example":{"error":"not found"}}}
+			  },
+			  "200":{`, 1)
+	specPath := writeFile(t, dir, "spec.json", spec)
+	writeFileWithDirs(t, dir, filepath.Join("items", "{id}", "GET.json"), `{"id":"{{.Path.id}}"}`)
+
+	s, err := New(Config{
+		Port:           "0",
+		SpecPath:       specPath,
+		SamplesDir:     dir,
+		FallbackMode:   config.FallbackOpenAPIExample,
+		ValidationMode: config.ValidationRequired,
+		Layout:         config.LayoutFolders,
+		Templating:     true,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	cases := []struct {
+		prefer string
+		status int
+		body   string
+	}{
+		// the code the sample is served with: the sample, templated
+		{"code=200", 200, `{"id":"7"}`},
+		// any other code bypasses the sample
+		{"code=404", 404, `{"error":"not found"}`},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodGet, "http://example.com/items/7", nil)
+		req.Header.Set("Prefer", tc.prefer)
+		rr := httptest.NewRecorder()
+		s.handle(rr, req)
+		if rr.Code != tc.status || strings.TrimSpace(rr.Body.String()) != tc.body {
+			t.Fatalf("Prefer %q: expected %d %s, got %d %s", tc.prefer, tc.status, tc.body, rr.Code, rr.Body.String())
+		}
+	}
+}
@@ -5,8 +5,10 @@
 package server
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
@@ -18,69 +20,91 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// branchHeader selects the oneOf/anyOf branch of bodies generated from the
+// spec, e.g. a discriminator value such as "cat".
+const branchHeader = "X-Emulator-Branch"
+
+// seedHeader overrides the configured generator seed for one request.
+const seedHeader = "X-Emulator-Seed"
+
 type Config struct {
-	Port           string
-	SpecPath       string
-	SamplesDir     string
-	FallbackMode   config.FallbackMode
-	ValidationMode config.ValidationMode
-	Layout         config.LayoutMode
+	Port               string
+	SpecPath           string
+	SamplesDir         string
+	FallbackMode       config.FallbackMode
+	ValidationMode     config.ValidationMode
+	SpecValidation     config.SpecValidationMode
+	ResponseValidation config.ResponseValidationMode
+	Negotiation        config.NegotiationMode
+	Layout             config.LayoutMode
+	BasePathMode       config.BasePathMode
+
+	// Mounts serves several specs under their own prefixes. When empty,
+	// SpecPath and SamplesDir are served from the root.
+	Mounts []config.MountConfig
+
+	// Watch reloads spec and samples when files change on disk.
+	Watch         bool
+	WatchInterval time.Duration
+
+	// ErrorFormat selects the built-in renderer for errors the emulator
+	// generates itself; ErrorRenderer replaces it when set.
+	ErrorFormat   config.ErrorFormat
+	ErrorRenderer IErrorRenderer
+
+	// Security enforces the security requirements of the operations.
+	Security config.SecurityConfig
+
+	// Generator controls bodies generated from response schemas.
+	Generator config.GeneratorConfig
+
+	// Pagination pages list responses by the declared paging params.
+	Pagination config.PaginationConfig
+
+	// Templating renders sample bodies and headers as templates of the
+	// request.
+	Templating bool
 }
 
 type Server struct {
-	cfg            Config
-	specProvider   openapi.ISpecProvider
-	routerProvider openapi.IRouterProvider
-	validator      openapi.IValidator
-	sampleProvider samples.ISampleProvider
-	log            *logrus.Logger
-
-	scenario samples.IScenarioResolver
+	cfg      Config
+	mounts   []*mount
+	renderer IErrorRenderer
+	log      *logrus.Logger
 }
 
 func New(cfg Config) (*Server, error) {
 	log := logger.GetLogger()
 
-	specProvider, err := openapi.NewSpecProvider(cfg.SpecPath, log)
-	if err != nil {
-		return nil, err
-	}
-
-	sp, ok := specProvider.(*openapi.SpecProvider)
-	if !ok {
-		return nil, fmt.Errorf("unexpected spec provider type: %T", specProvider)
-	}
-
-	routeProvider := openapi.NewRouterProvider(sp.GetSpec())
-	validator := openapi.NewValidator(specProvider)
-
 	if strings.TrimSpace(string(cfg.Layout)) == "" {
 		cfg.Layout = config.LayoutAuto
 	}
-
-	s := &Server{
-		cfg:            cfg,
-		specProvider:   specProvider,
-		routerProvider: routeProvider,
-		validator:      validator,
-		log:            log,
+	if strings.TrimSpace(string(cfg.BasePathMode)) == "" {
+		cfg.BasePathMode = config.BasePathAuto
 	}
-
-	providerCfg := samples.ProviderConfig{
-		BaseDir:          cfg.SamplesDir,
-		Layout:           cfg.Layout,
-		ScenarioEnabled:  config.Envs.Scenario.Enabled,
-		ScenarioFilename: config.Envs.Scenario.Filename,
+	if strings.TrimSpace(string(cfg.Negotiation)) == "" {
+		cfg.Negotiation = config.NegotiationStrict
+	}
+	if cfg.WatchInterval <= 0 {
+		cfg.WatchInterval = time.Second
 	}
 
-	if config.Envs.Scenario.Enabled {
-		s.scenario = samples.NewScenarioResolver()
-		providerCfg.ScenarioResolver = s.scenario
+	mounts, err := buildMounts(cfg, log)
+	if err != nil {
+		return nil, err
 	}
 
-	s.sampleProvider = samples.NewSampleProvider(providerCfg, log)
+	renderer := cfg.ErrorRenderer
+	if renderer == nil {
+		renderer = NewErrorRenderer(cfg.ErrorFormat)
+	}
 
-	return s, nil
+	return &Server{
+		cfg:      cfg,
+		mounts:   mounts,
+		renderer: renderer,
+		log:      log,
+	}, nil
 }
 
 func (s *Server) ListenAndServe() error {
@@ -91,10 +115,25 @@ func (s *Server) ListenAndServe() error {
 
 	s.log.Printf("mock listening on %s", addr)
 	s.log.Printf(
-		"spec=%s samples=%s fallback=%s validation=%s layout=%s scenario_enabled=%v scenario_file=%q",
-		s.cfg.SpecPath, s.cfg.SamplesDir, s.cfg.FallbackMode, s.cfg.ValidationMode,
-		s.cfg.Layout, config.Envs.Scenario.Enabled, config.Envs.Scenario.Filename,
+		"fallback=%s validation=%s negotiation=%s layout=%s base_path=%s scenario_enabled=%v scenario_file=%q",
+		s.cfg.FallbackMode, s.cfg.ValidationMode, s.cfg.Negotiation,
+		s.cfg.Layout, s.cfg.BasePathMode, config.Envs.Scenario.Enabled, config.Envs.Scenario.Filename,
 	)
+	for _, m := range s.mounts {
+		report := m.current().specReport
+		s.log.Printf("mount prefix=%q spec=%s samples=%s spec_issues=%d",
+			m.prefix, m.specPath, m.samplesDir, len(report.Issues))
+		if !report.OK() {
+			s.log.Warn(report.String())
+		}
+	}
+
+	if s.cfg.Watch {
+		done := make(chan struct{})
+		defer close(done)
+		go s.watch(done)
+		s.log.Printf("watching spec and samples every %s", s.cfg.WatchInterval)
+	}
 
 	server := &http.Server{
 		Addr:              addr,
@@ -118,59 +157,215 @@ func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	rt := s.routerProvider.FindRoute(method, path)
-	if rt == nil {
-		utils.WriteJSON(w, 404, map[string]any{
-			"error":  "No route",
-			"method": method,
-			"path":   path,
+	if method == http.MethodHead {
+		w = headResponseWriter{w}
+	}
+
+	// route on the escaped path so encoded slashes stay inside their segment;
+	// the router decodes path parameters itself
+	m, relPath := s.findMount(r.URL.EscapedPath())
+	if m == nil {
+		s.renderer.Render(w, r, &Problem{
+			Status:     404,
+			Title:      "No route",
+			Extensions: map[string]any{"method": method, "path": path},
+		})
+		return
+	}
+
+	snap := m.current()
+
+	match, err := snap.routerProvider.FindRoute(method, relPath)
+
+	var notAllowed *openapi.MethodNotAllowedError
+	if method == http.MethodHead && errors.As(err, &notAllowed) && slices.Contains(notAllowed.Allowed, http.MethodGet) {
+		// HEAD is answered from the GET route without a body
+		match, err = snap.routerProvider.FindRoute(http.MethodGet, relPath)
+	}
+
+	if errors.As(err, &notAllowed) {
+		w.Header().Set("Allow", allowHeader(notAllowed.Allowed))
+		if method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		s.renderer.Render(w, r, &Problem{
+			Status:     405,
+			Title:      "Method Not Allowed",
+			Extensions: map[string]any{"method": method, "path": path, "allowed": notAllowed.Allowed},
 		})
 		return
 	}
 
-	if s.cfg.ValidationMode == config.ValidationRequired {
-		if s.validator.HasRequiredBodyParam(rt.Swagger, rt.Method) {
-			empty, err := s.validator.IsEmptyBody(r)
+	if err != nil {
+		p := &Problem{
+			Status:     404,
+			Title:      "No route",
+			Extensions: map[string]any{"method": method, "path": path},
+		}
+		var perr *openapi.ParamError
+		if errors.As(err, &perr) {
+			p.Detail = perr.Error()
+			p.Extensions["swaggerPath"] = perr.Template
+			p.Extensions["param"] = perr.Param
+		}
+		s.renderer.Render(w, r, p)
+		return
+	}
+	rt := match.Route
+
+	if s.cfg.Security.Enabled {
+		if f := snap.validator.CheckSecurity(r, match, s.cfg.Security.Credentials); f != nil {
+			s.writeSecurityFailure(w, r, snap, match, f)
+			return
+		}
+	}
+
+	if cf := snap.validator.CheckContentType(r, match); cf != nil && s.cfg.Negotiation == config.NegotiationStrict {
+		s.writeContentFailure(w, r, snap, match, cf)
+		return
+	}
+
+	switch s.cfg.ValidationMode {
+	case config.ValidationRequired:
+		if snap.validator.HasRequiredBodyParam(rt.Swagger, rt.Method) {
+			empty, err := snap.validator.IsEmptyBody(r)
 			if err != nil {
-				utils.WriteJSON(w, 400, map[string]any{"error": "Bad Request", "details": err.Error()})
+				s.renderer.Render(w, r, snap.problem(match, 400, "Bad Request", err.Error(), nil))
 				return
 			}
 			if empty {
-				utils.WriteJSON(w, 400, map[string]any{
-					"error":   "Bad Request",
-					"details": "Request body is required by the API spec",
-				})
+				s.renderer.Render(w, r, snap.problem(match, 400, "Bad Request",
+					"Request body is required by the API spec", nil))
 				return
 			}
 		}
+	case config.ValidationStrict:
+		if violations := snap.validator.ValidateRequest(r, match); len(violations) > 0 {
+			s.renderer.Render(w, r, snap.problem(match, 400, "Bad Request",
+				"Request does not match the API spec", map[string]any{
+					"swaggerPath": rt.Swagger,
+					"violations":  violations,
+				}))
+			return
+		}
 	}
 
-	resp, err := s.sampleProvider.ResolveAndLoad(
-		method,
-		rt.Swagger,
-		path,
-		rt.SampleFile,
-	)
+	pref := parsePrefer(r.Header.Values("Prefer"))
+	if pref.example != "" || (pref.code != 0 && s.hasStatusSample(snap, match, pref.code)) {
+		s.servePreferred(w, r, snap, match, pref)
+		return
+	}
+
+	paging := s.paging(snap, rt)
+
+	resp, err := snap.sampleProvider.ResolveAndLoad(rt.Method, match)
+	if pref.code != 0 && pref.code != s.resolvedStatus(r, snap, match, resp, err) {
+		s.servePreferred(w, r, snap, match, pref)
+		return
+	}
 	if err != nil {
 		if s.cfg.FallbackMode == config.FallbackOpenAPIExample {
-			if body, ok := s.specProvider.TryGetExampleBody(rt.Swagger, rt.Method); ok {
-				w.Header().Set("content-type", "application/json")
-				w.WriteHeader(200)
-				_, _ = w.Write(body)
+			mediaType, ok := s.negotiate(w, r, snap, match, 0, "")
+			if !ok {
+				return
+			}
+			opts := s.exampleOptions(r, rt.Method, mediaType)
+			if paging != nil {
+				opts.ListLength = s.cfg.Pagination.Total
+			}
+			if ex, ok := snap.specProvider.TryGetExample(rt.Swagger, rt.Method, opts); ok {
+				if paging != nil {
+					ex.Body, ex.Headers = paginate(paging, r, ex.Status, ex.Body, ex.Headers)
+				}
+				writeExample(w, ex, ex.Status)
 				return
 			}
 		}
 
-		utils.WriteJSON(w, 501, map[string]any{
-			"error":              "No sample file for route",
+		s.renderer.Render(w, r, snap.problem(match, 501, "No sample file for route", err.Error(), map[string]any{
 			"method":             method,
 			"path":               path,
+			"mount":              m.prefix,
 			"swaggerPath":        rt.Swagger,
 			"legacyFlatFilename": rt.SampleFile,
 			"layout":             s.cfg.Layout,
-			"details":            err.Error(),
 			"hint":               "Create the sample file under SAMPLES_DIR/<path>/<METHOD>[.<state>].json (or legacy flat), or set FALLBACK_MODE=openapi_examples and add examples to swagger.json",
-		})
+		}))
+		return
+	}
+
+	mediaType, ok := s.negotiate(w, r, snap, match, resp.Status, "")
+	if !ok {
+		return
+	}
+	if !s.renderSample(w, r, snap, match, resp) {
+		return
+	}
+	if paging != nil {
+		resp.Body, resp.Headers = paginate(paging, r, resp.Status, resp.Body, resp.Headers)
+	}
+	s.writeSample(w, r, snap, match, resp, mediaType)
+}
+
+// hasStatusSample reports whether a status sample file such as
+// GET.404.json exists for the route.
+func (s *Server) hasStatusSample(snap *snapshot, match *openapi.RouteMatch, status int) bool {
+	_, err := snap.sampleProvider.LoadStatusSample(match.Route.Method, match, status)
+	return err == nil
+}
+
+// resolvedStatus returns the status the request is answered with without
+// a Prefer header: that of the resolved sample, else that of the spec
+// fallback, or 0 when there is neither.
+func (s *Server) resolvedStatus(r *http.Request, snap *snapshot, match *openapi.RouteMatch, resp *samples.Response, err error) int {
+	if err == nil {
+		return resp.Status
+	}
+	if s.cfg.FallbackMode != config.FallbackOpenAPIExample {
+		return 0
+	}
+	rt := match.Route
+	ex, ok := snap.specProvider.TryGetExample(rt.Swagger, rt.Method, s.exampleOptions(r, rt.Method, ""))
+	if !ok {
+		return 0
+	}
+	if ex.Status == 0 {
+		return http.StatusOK
+	}
+	return ex.Status
+}
+
+// negotiate returns the media type, picked by the Accept header, of the
+// response served with status (0 for the best response) or the named
+// example. In strict mode a success response the client does not accept
+// is answered with 406 and false is returned; error responses are served
+// in their declared media type instead.
+func (s *Server) negotiate(w http.ResponseWriter, r *http.Request, snap *snapshot, match *openapi.RouteMatch, status int, example string) (string, bool) {
+	mediaType, cf := snap.validator.NegotiateAccept(r, match, status, example)
+	if cf == nil || s.cfg.Negotiation != config.NegotiationStrict || status >= 400 {
+		return mediaType, true
+	}
+	s.writeContentFailure(w, r, snap, match, cf)
+	return "", false
+}
+
+// writeContentFailure answers with the 415 or 406 of cf.
+func (s *Server) writeContentFailure(w http.ResponseWriter, r *http.Request, snap *snapshot, match *openapi.RouteMatch, cf *openapi.ContentFailure) {
+	s.renderer.Render(w, r, snap.problem(match, cf.Status, http.StatusText(cf.Status), cf.Reason, map[string]any{
+		"method":      r.Method,
+		"path":        r.URL.Path,
+		"swaggerPath": match.Route.Swagger,
+		"supported":   cf.Supported,
+	}))
+}
+
+// writeSample writes a sample response labelled with the negotiated media
+// type, after checking it against the spec.
+func (s *Server) writeSample(w http.ResponseWriter, r *http.Request, snap *snapshot, match *openapi.RouteMatch, resp *samples.Response, mediaType string) {
+	applyMediaType(resp, mediaType)
+
+	if s.checkResponse(w, r, snap, match, resp) {
 		return
 	}
 
@@ -181,10 +376,235 @@ func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
 	_, _ = w.Write(resp.Body)
 }
 
+// writeExample writes a response taken from the spec with status, or 200
+// when it is 0. Bodiless responses such as 204 get neither a body nor a
+// content-type.
+func writeExample(w http.ResponseWriter, ex *openapi.Example, status int) {
+	if status == 0 {
+		status = http.StatusOK
+	}
+	for k, v := range ex.Headers {
+		w.Header().Set(k, v)
+	}
+	if ex.MediaType != "" && len(ex.Body) > 0 {
+		w.Header().Set("content-type", ex.MediaType)
+	}
+	w.WriteHeader(status)
+	if status != http.StatusNoContent && status != http.StatusNotModified {
+		_, _ = w.Write(ex.Body)
+	}
+}
+
+// exampleOptions returns how the spec fallback picks and generates the
+// body for r, answered from the route of method.
+func (s *Server) exampleOptions(r *http.Request, method, mediaType string) openapi.ExampleOptions {
+	opts := openapi.ExampleOptions{
+		MediaType:   mediaType,
+		Branch:      r.Header.Get(branchHeader),
+		MaxDepth:    s.cfg.Generator.MaxDepth,
+		ArrayLength: s.cfg.Generator.ArrayLength,
+	}
+	if s.cfg.Generator.Mode == config.GeneratorFake {
+		seed := s.cfg.Generator.Seed
+		if h := r.Header.Get(seedHeader); h != "" {
+			seed = h
+		}
+		opts.Random = true
+		opts.Seed = openapi.MixSeed(seed, method, r.URL.Path)
+	}
+	return opts
+}
+
+// applyMediaType labels a JSON sample with the negotiated JSON media type,
+// e.g. application/vnd.api+json, when the sample itself says
+// application/json.
+func applyMediaType(resp *samples.Response, mediaType string) {
+	if mediaType == "" || !openapi.IsJSONMediaType(mediaType) {
+		return
+	}
+	for k, v := range resp.Headers {
+		if strings.EqualFold(k, "content-type") && strings.EqualFold(strings.TrimSpace(v), "application/json") {
+			resp.Headers[k] = mediaType
+		}
+	}
+}
+
+// checkResponse validates a sample response against the spec according to
+// the response validation mode. It reports true when it already wrote a
+// replacement response.
+func (s *Server) checkResponse(w http.ResponseWriter, r *http.Request, snap *snapshot, match *openapi.RouteMatch, resp *samples.Response) bool {
+	mode := s.cfg.ResponseValidation
+	if mode == "" || mode == config.ResponseValidationNone {
+		return false
+	}
+
+	header := http.Header{}
+	for k, v := range resp.Headers {
+		header.Set(k, v)
+	}
+
+	violations := snap.validator.ValidateResponse(r, match, resp.Status, header, resp.Body)
+	if len(violations) == 0 {
+		return false
+	}
+
+	msgs := make([]string, len(violations))
+	for i, v := range violations {
+		msgs[i] = v.In
+		if v.Name != "" {
+			msgs[i] += " " + v.Name
+		}
+		msgs[i] += ": " + v.Message
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"method":      r.Method,
+		"path":        r.URL.Path,
+		"swaggerPath": match.Route.Swagger,
+		"status":      resp.Status,
+		"violations":  msgs,
+	}).Warn("sample response does not match the spec")
+
+	switch mode {
+	case config.ResponseValidationHeader:
+		w.Header().Set("X-Emulator-Response-Warning", strings.Join(msgs, "; "))
+	case config.ResponseValidationFail:
+		s.renderer.Render(w, r, snap.problem(match, 500, "Sample response does not match the API spec", "", map[string]any{
+			"method":       r.Method,
+			"path":         r.URL.Path,
+			"swaggerPath":  match.Route.Swagger,
+			"sampleStatus": resp.Status,
+			"violations":   violations,
+		}))
+		return true
+	}
+	return false
+}
+
+// writeSecurityFailure answers a request rejected by the security check.
+// The body comes from a <METHOD>.<status>.json sample, then from the spec
+// example for the status, then from the error renderer. Headers of the
+// sample or the declared response, such as WWW-Authenticate, replace the
+// computed challenges.
+func (s *Server) writeSecurityFailure(w http.ResponseWriter, r *http.Request, snap *snapshot, match *openapi.RouteMatch, f *openapi.SecurityFailure) {
+	rt := match.Route
+
+	s.log.WithFields(logrus.Fields{
+		"method":      r.Method,
+		"path":        r.URL.Path,
+		"swaggerPath": rt.Swagger,
+		"status":      f.Status,
+		"scheme":      f.Scheme,
+	}).Info(f.Reason)
+
+	for _, c := range f.Challenges {
+		w.Header().Add("WWW-Authenticate", c)
+	}
+
+	if resp, err := snap.sampleProvider.LoadStatusSample(rt.Method, match, f.Status); err == nil {
+		if !s.renderSample(w, r, snap, match, resp) {
+			return
+		}
+		for k, v := range resp.Headers {
+			w.Header().Set(k, v)
+		}
+		w.WriteHeader(f.Status)
+		_, _ = w.Write(resp.Body)
+		return
+	}
+
+	mediaType, _ := s.negotiate(w, r, snap, match, f.Status, "")
+	if ex, ok := snap.specProvider.TryGetStatusExample(rt.Swagger, rt.Method, f.Status, mediaType); ok {
+		writeExample(w, ex, f.Status)
+		return
+	}
+
+	s.renderer.Render(w, r, snap.problem(match, f.Status, http.StatusText(f.Status), f.Reason, map[string]any{
+		"method": r.Method,
+		"path":   r.URL.Path,
+		"scheme": f.Scheme,
+	}))
+}
+
+// watch polls every mount for file changes until done is closed.
+func (s *Server) watch(done <-chan struct{}) {
+	watchers := make([]*fileWatcher, len(s.mounts))
+	for i, m := range s.mounts {
+		watchers[i] = newFileWatcher(m.specPath, m.samplesDir)
+	}
+
+	t := time.NewTicker(s.cfg.WatchInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-t.C:
+			s.reloadChanged(watchers)
+		}
+	}
+}
+
+func (s *Server) reloadChanged(watchers []*fileWatcher) {
+	for i, m := range s.mounts {
+		changed := watchers[i].poll()
+		if len(changed) == 0 {
+			continue
+		}
+
+		log := s.log.WithFields(logrus.Fields{"mount": m.prefix, "changed": len(changed)})
+		if err := m.reload(changed); err != nil {
+			log.WithError(err).Error("reload failed; keeping previous spec and samples")
+			continue
+		}
+		log.Info("reloaded spec and samples")
+	}
+}
+
+// findMount returns the mount serving path and the path relative to its
+// prefix, or nil when no mount matches.
+func (s *Server) findMount(path string) (*mount, string) {
+	for _, m := range s.mounts {
+		if rel, ok := m.match(path); ok {
+			return m, rel
+		}
+	}
+	return nil, ""
+}
+
+// allowHeader lists the spec methods plus the ones answered automatically:
+// HEAD when GET exists, and OPTIONS.
+func allowHeader(specMethods []string) string {
+	methods := slices.Clone(specMethods)
+	if slices.Contains(methods, http.MethodGet) && !slices.Contains(methods, http.MethodHead) {
+		methods = append(methods, http.MethodHead)
+	}
+	if !slices.Contains(methods, http.MethodOptions) {
+		methods = append(methods, http.MethodOptions)
+	}
+	slices.Sort(methods)
+	return strings.Join(methods, ", ")
+}
+
+// headResponseWriter sends status and headers but drops the body.
+type headResponseWriter struct {
+	http.ResponseWriter
+}
+
+func (w headResponseWriter) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
 func (s *Server) DebugRoutes() string {
 	out := ""
-	for _, r := range s.routerProvider.GetRoutes() {
-		out += fmt.Sprintf("%s %s -> %s\n", r.Method, r.Swagger, r.SampleFile)
+	for _, m := range s.mounts {
+		if len(s.mounts) > 1 || m.prefix != "" {
+			out += fmt.Sprintf("# mount %q spec=%s samples=%s\n", m.prefix, m.specPath, m.samplesDir)
+		}
+		for _, r := range m.current().routerProvider.GetRoutes() {
+			out += fmt.Sprintf("%s %s%s -> %s\n", r.Method, m.prefix, r.Swagger, r.SampleFile)
+		}
 	}
 	return out
 }
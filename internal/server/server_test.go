@@ -36,20 +36,27 @@ func TestNew_LoadsSpecAndBuildsRoutes(t *testing.T) {
 	if s == nil {
 		t.Fatalf("expected server, got nil")
 	}
-	if s.specProvider == nil {
+	if len(s.mounts) != 1 {
+		t.Fatalf("expected one root mount, got %d", len(s.mounts))
+	}
+	if s.mounts[0].prefix != "" {
+		t.Fatalf("expected root mount, got prefix %q", s.mounts[0].prefix)
+	}
+	m := s.mounts[0].current()
+	if m.specProvider == nil {
 		t.Fatalf("expected specProvider")
 	}
-	if s.routerProvider == nil {
+	if m.routerProvider == nil {
 		t.Fatalf("expected routerProvider")
 	}
-	if s.validator == nil {
+	if m.validator == nil {
 		t.Fatalf("expected validator")
 	}
-	if s.sampleProvider == nil {
+	if m.sampleProvider == nil {
 		t.Fatalf("expected sampleProvider")
 	}
 
-	routes := s.routerProvider.GetRoutes()
+	routes := m.routerProvider.GetRoutes()
 	if len(routes) == 0 {
 		t.Fatalf("expected routes, got none")
 	}
@@ -88,9 +95,12 @@ func TestHandle_NoRoute_404(t *testing.T) {
 	}
 	var m map[string]any
 	_ = json.Unmarshal(rr.Body.Bytes(), &m)
-	if m["error"] != "No route" {
+	if m["title"] != "No route" || m["status"] != float64(404) || m["instance"] != "/does-not-exist" {
 		t.Fatalf("unexpected body: %v", m)
 	}
+	if ct := rr.Header().Get("content-type"); ct != "application/problem+json" {
+		t.Fatalf("expected problem+json, got %q", ct)
+	}
 }
 
 func TestHandle_ValidationRequired_EmptyBody_400(t *testing.T) {
@@ -106,7 +116,7 @@ func TestHandle_ValidationRequired_EmptyBody_400(t *testing.T) {
 	}
 	var m map[string]any
 	_ = json.Unmarshal(rr.Body.Bytes(), &m)
-	if m["error"] != "Bad Request" {
+	if m["title"] != "Bad Request" || m["detail"] != "Request body is required by the API spec" {
 		t.Fatalf("unexpected: %v", m)
 	}
 }
@@ -130,6 +140,47 @@ func TestHandle_ValidationRequired_NonEmptyBody_AllowsSample(t *testing.T) {
 	}
 }
 
+func TestHandle_ValidationStrict_ViolationsList_400(t *testing.T) {
+	s := newTestServer(t, config.ValidationStrict, config.FallbackNone)
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "http://example.com/items", strings.NewReader(`[1]`))
+	req.Header.Set("Content-Type", "application/json")
+
+	s.handle(rr, req)
+
+	if rr.Code != 400 {
+		t.Fatalf("expected 400, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var m struct {
+		Title      string `json:"title"`
+		Violations []struct {
+			In      string `json:"in"`
+			Message string `json:"message"`
+		} `json:"violations"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &m); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if m.Title != "Bad Request" || len(m.Violations) != 1 || m.Violations[0].In != "body" {
+		t.Fatalf("unexpected body: %s", rr.Body.String())
+	}
+}
+
+func TestHandle_ValidationStrict_ValidRequest_ServesSample(t *testing.T) {
+	s := newTestServer(t, config.ValidationStrict, config.FallbackNone)
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "http://example.com/items", strings.NewReader(`{"a":1}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	s.handle(rr, req)
+
+	if rr.Code != 201 {
+		t.Fatalf("expected 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
 func TestHandle_SampleFound_WritesHeadersStatusBody(t *testing.T) {
 	s := newTestServer(t, config.ValidationRequired, config.FallbackOpenAPIExample)
 
@@ -149,6 +200,59 @@ func TestHandle_SampleFound_WritesHeadersStatusBody(t *testing.T) {
 	}
 }
 
+func TestHandle_ResponseValidation_Modes(t *testing.T) {
+	cases := []struct {
+		mode       config.ResponseValidationMode
+		wantStatus int
+		wantHeader bool
+	}{
+		{config.ResponseValidationNone, 202, false},
+		{config.ResponseValidationLog, 202, false},
+		{config.ResponseValidationHeader, 202, true},
+		{config.ResponseValidationFail, 500, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(string(tc.mode), func(t *testing.T) {
+			s := newTestServer(t, config.ValidationNone, config.FallbackNone)
+			s.cfg.ResponseValidation = tc.mode
+
+			// 202 is not declared for GET /items/{id}
+			writeFileWithDirs(t, s.mounts[0].samplesDir, filepath.Join("items", "{id}", "GET.json"),
+				`{"status":202,"body":{"id":"1"}}`)
+
+			rr := httptest.NewRecorder()
+			s.handle(rr, httptest.NewRequest(http.MethodGet, "http://example.com/items/1", nil))
+
+			if rr.Code != tc.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tc.wantStatus, rr.Code, rr.Body.String())
+			}
+			warning := rr.Header().Get("X-Emulator-Response-Warning")
+			if (warning != "") != tc.wantHeader {
+				t.Fatalf("unexpected warning header %q", warning)
+			}
+			if tc.wantHeader && !strings.Contains(warning, "status 202 is not declared") {
+				t.Fatalf("expected status violation in header, got %q", warning)
+			}
+			if tc.wantStatus == 500 && !strings.Contains(rr.Body.String(), `"violations"`) {
+				t.Fatalf("expected violations in body, got %s", rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandle_ResponseValidation_ValidSampleUntouched(t *testing.T) {
+	s := newTestServer(t, config.ValidationNone, config.FallbackNone)
+	s.cfg.ResponseValidation = config.ResponseValidationFail
+
+	rr := httptest.NewRecorder()
+	s.handle(rr, httptest.NewRequest(http.MethodGet, "http://example.com/items/123", nil))
+
+	if rr.Code != 200 || rr.Header().Get("X-Emulator-Response-Warning") != "" {
+		t.Fatalf("expected untouched 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
 func TestHandle_SampleMissing_FallbackOpenAPIExample_200(t *testing.T) {
 	disableScenarioForTests()
 
@@ -183,6 +287,50 @@ func TestHandle_SampleMissing_FallbackOpenAPIExample_200(t *testing.T) {
 	}
 }
 
+func TestHandle_SampleMissing_FallbackUsesSpecStatusAndHeaders(t *testing.T) {
+	disableScenarioForTests()
+
+	dir := t.TempDir()
+	spec := strings.Replace(minimalSpec(), `"201":{
+				"description":"created",`, `"201":{
+				"description":"created",
+				"headers":{
+				  "Location":{"schema":{"type":"string"},"example":"/items/42"}
+				},`, 1)
+	spec = strings.Replace(spec, `"/items/{id}":{
+		  "get":{`, `"/items/{id}":{
+		  "delete":{"responses":{"204":{"description":"deleted"}}},
+		  "get":{`, 1)
+	specPath := writeFile(t, dir, "spec.json", spec)
+
+	s, err := New(Config{
+		Port:           "0",
+		SpecPath:       specPath,
+		SamplesDir:     dir,
+		FallbackMode:   config.FallbackOpenAPIExample,
+		ValidationMode: config.ValidationNone,
+		Layout:         config.LayoutFolders,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	rr := httptest.NewRecorder()
+	s.handle(rr, httptest.NewRequest(http.MethodPost, "http://example.com/items", strings.NewReader(`{}`)))
+	if rr.Code != 201 || rr.Header().Get("Location") != "/items/42" {
+		t.Fatalf("expected 201 with Location, got %d %v", rr.Code, rr.Header())
+	}
+	if strings.TrimSpace(rr.Body.String()) != `{"created":true}` {
+		t.Fatalf("unexpected body: %q", rr.Body.String())
+	}
+
+	rr = httptest.NewRecorder()
+	s.handle(rr, httptest.NewRequest(http.MethodDelete, "http://example.com/items/1", nil))
+	if rr.Code != 204 || rr.Body.Len() != 0 || rr.Header().Get("content-type") != "" {
+		t.Fatalf("expected an empty 204, got %d %v %q", rr.Code, rr.Header(), rr.Body.String())
+	}
+}
+
 func TestHandle_SampleMissing_NoFallback_501(t *testing.T) {
 	disableScenarioForTests()
 
@@ -212,7 +360,7 @@ func TestHandle_SampleMissing_NoFallback_501(t *testing.T) {
 	var m map[string]any
 	_ = json.Unmarshal(rr.Body.Bytes(), &m)
 
-	if m["error"] != "No sample file for route" {
+	if m["title"] != "No sample file for route" {
 		t.Fatalf("unexpected: %v", m)
 	}
 	if m["swaggerPath"] != "/items/{id}" {
@@ -241,6 +389,742 @@ func TestDebugRoutes_NotEmptyAndContainsMappings(t *testing.T) {
 	}
 }
 
+func TestHandle_Mounts_RoutesByPrefix(t *testing.T) {
+	disableScenarioForTests()
+
+	dirA := t.TempDir()
+	specA := writeFile(t, dirA, "spec.json", minimalSpec())
+	writeFileWithDirs(t, dirA, filepath.Join("items", "{id}", "GET.json"), `{"mount":"a"}`)
+
+	dirB := t.TempDir()
+	specB := writeFile(t, dirB, "spec.json", minimalSpec())
+	writeFileWithDirs(t, dirB, filepath.Join("items", "{id}", "GET.json"), `{"mount":"b"}`)
+
+	s, err := New(Config{
+		Port:           "0",
+		FallbackMode:   config.FallbackNone,
+		ValidationMode: config.ValidationRequired,
+		Layout:         config.LayoutFolders,
+		Mounts: []config.MountConfig{
+			{Prefix: "/a", SpecPath: specA, SamplesDir: dirA},
+			{Prefix: "/b/", SpecPath: specB, SamplesDir: dirB},
+		},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	for _, tc := range []struct{ path, want string }{
+		{"/a/items/1", `{"mount":"a"}`},
+		{"/b/items/1", `{"mount":"b"}`},
+	} {
+		rr := httptest.NewRecorder()
+		s.handle(rr, httptest.NewRequest(http.MethodGet, "http://example.com"+tc.path, nil))
+
+		if rr.Code != 200 {
+			t.Fatalf("%s: expected 200, got %d: %s", tc.path, rr.Code, rr.Body.String())
+		}
+		if strings.TrimSpace(rr.Body.String()) != tc.want {
+			t.Fatalf("%s: unexpected body: %q", tc.path, rr.Body.String())
+		}
+	}
+
+	rr := httptest.NewRecorder()
+	s.handle(rr, httptest.NewRequest(http.MethodGet, "http://example.com/items/1", nil))
+	if rr.Code != 404 {
+		t.Fatalf("expected 404 outside every mount, got %d", rr.Code)
+	}
+
+	out := s.DebugRoutes()
+	for _, want := range []string{`# mount "/a"`, `# mount "/b"`, "GET /a/items/{id} ->", "GET /b/items/{id} ->"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in DebugRoutes output:\n%s", want, out)
+		}
+	}
+}
+
+func TestHandle_ServersBasePath_WithAndWithoutPrefix(t *testing.T) {
+	disableScenarioForTests()
+
+	dir := t.TempDir()
+	spec := strings.Replace(minimalSpec(), `"info":{"title":"t","version":"1"},`,
+		`"info":{"title":"t","version":"1"},"servers":[{"url":"/openvasd"}],`, 1)
+	specPath := writeFile(t, dir, "spec.json", spec)
+	writeFileWithDirs(t, dir, filepath.Join("items", "{id}", "GET.json"), `{"id":"1"}`)
+
+	s, err := New(Config{
+		Port:         "0",
+		SpecPath:     specPath,
+		SamplesDir:   dir,
+		FallbackMode: config.FallbackNone,
+		Layout:       config.LayoutFolders,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	for _, p := range []string{"/openvasd/items/1", "/items/1"} {
+		rr := httptest.NewRecorder()
+		s.handle(rr, httptest.NewRequest(http.MethodGet, "http://example.com"+p, nil))
+		if rr.Code != 200 || strings.TrimSpace(rr.Body.String()) != `{"id":"1"}` {
+			t.Fatalf("%s: expected sample, got %d: %s", p, rr.Code, rr.Body.String())
+		}
+	}
+}
+
+func TestHandle_Scenario_KeyFromDecodedParamUnderBasePath(t *testing.T) {
+	disableScenarioForTests()
+	config.Envs.Scenario.Enabled = true
+	t.Cleanup(disableScenarioForTests)
+
+	dir := t.TempDir()
+	spec := strings.Replace(minimalSpec(), `"info":{"title":"t","version":"1"},`,
+		`"info":{"title":"t","version":"1"},"servers":[{"url":"/openvasd"}],`, 1)
+	specPath := writeFile(t, dir, "spec.json", spec)
+	writeFileWithDirs(t, dir, filepath.Join("items", "{id}", config.Envs.Scenario.Filename), `{
+	  "version": 1,
+	  "mode": "step",
+	  "key": {"pathParam": "id"},
+	  "sequence": [{"state":"a","file":"GET.a.json"},{"state":"b","file":"GET.b.json"}],
+	  "behavior": {"advanceOn": [{"method":"GET"}], "repeatLast": true}
+	}`)
+	writeFileWithDirs(t, dir, filepath.Join("items", "{id}", "GET.a.json"), `{"state":"a"}`)
+	writeFileWithDirs(t, dir, filepath.Join("items", "{id}", "GET.b.json"), `{"state":"b"}`)
+
+	s, err := New(Config{
+		Port:         "0",
+		SpecPath:     specPath,
+		SamplesDir:   dir,
+		FallbackMode: config.FallbackNone,
+		Layout:       config.LayoutFolders,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	get := func(p string) string {
+		rr := httptest.NewRecorder()
+		s.handle(rr, httptest.NewRequest(http.MethodGet, "http://example.com"+p, nil))
+		if rr.Code != 200 {
+			t.Fatalf("%s: expected 200, got %d: %s", p, rr.Code, rr.Body.String())
+		}
+		return strings.TrimSpace(rr.Body.String())
+	}
+
+	// "a%2Fb" and "a%2fb" decode to the same scenario key
+	if got := get("/openvasd/items/a%2Fb"); got != `{"state":"a"}` {
+		t.Fatalf("first request: %s", got)
+	}
+	if got := get("/items/a%2fb"); got != `{"state":"b"}` {
+		t.Fatalf("second request: %s", got)
+	}
+	if got := get("/openvasd/items/other"); got != `{"state":"a"}` {
+		t.Fatalf("other key: %s", got)
+	}
+}
+
+func TestHandle_PathParamTypeMismatch_404WithDetails(t *testing.T) {
+	disableScenarioForTests()
+
+	dir := t.TempDir()
+	spec := strings.Replace(minimalSpec(), `"get":{`,
+		`"get":{"parameters":[{"name":"id","in":"path","required":true,"schema":{"type":"integer"}}],`, 1)
+	specPath := writeFile(t, dir, "spec.json", spec)
+	writeFileWithDirs(t, dir, filepath.Join("items", "{id}", "GET.json"), `{"id":1}`)
+
+	s, err := New(Config{
+		Port:         "0",
+		SpecPath:     specPath,
+		SamplesDir:   dir,
+		FallbackMode: config.FallbackNone,
+		Layout:       config.LayoutFolders,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	rr := httptest.NewRecorder()
+	s.handle(rr, httptest.NewRequest(http.MethodGet, "http://example.com/items/1", nil))
+	if rr.Code != 200 {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = httptest.NewRecorder()
+	s.handle(rr, httptest.NewRequest(http.MethodGet, "http://example.com/items/abc", nil))
+	if rr.Code != 404 {
+		t.Fatalf("expected 404, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var body map[string]any
+	_ = json.Unmarshal(rr.Body.Bytes(), &body)
+	if body["param"] != "id" || body["swaggerPath"] != "/items/{id}" {
+		t.Fatalf("unexpected body: %v", body)
+	}
+	if detail, _ := body["detail"].(string); !strings.Contains(detail, "must be an integer") {
+		t.Fatalf("expected reason in detail, got %v", body["detail"])
+	}
+}
+
+func TestHandle_MethodNotAllowed_405WithAllow(t *testing.T) {
+	s := newTestServer(t, config.ValidationNone, config.FallbackNone)
+
+	rr := httptest.NewRecorder()
+	s.handle(rr, httptest.NewRequest(http.MethodDelete, "http://example.com/items/1", nil))
+
+	if rr.Code != 405 {
+		t.Fatalf("expected 405, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if got := rr.Header().Get("Allow"); got != "GET, HEAD, OPTIONS" {
+		t.Fatalf("unexpected Allow header %q", got)
+	}
+
+	rr = httptest.NewRecorder()
+	s.handle(rr, httptest.NewRequest(http.MethodDelete, "http://example.com/unknown", nil))
+	if rr.Code != 404 {
+		t.Fatalf("expected 404 for unknown path, got %d", rr.Code)
+	}
+}
+
+func TestHandle_Head_UsesGetRouteWithoutBody(t *testing.T) {
+	s := newTestServer(t, config.ValidationNone, config.FallbackNone)
+
+	rr := httptest.NewRecorder()
+	s.handle(rr, httptest.NewRequest(http.MethodHead, "http://example.com/items/123", nil))
+
+	if rr.Code != 200 {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	if rr.Header().Get("x-sample") != "1" {
+		t.Fatalf("expected sample headers, got %v", rr.Header())
+	}
+	if rr.Body.Len() != 0 {
+		t.Fatalf("expected no body, got %q", rr.Body.String())
+	}
+
+	// POST-only path: HEAD is not allowed
+	rr = httptest.NewRecorder()
+	s.handle(rr, httptest.NewRequest(http.MethodHead, "http://example.com/items", nil))
+	if rr.Code != 405 || rr.Body.Len() != 0 {
+		t.Fatalf("expected bodyless 405, got %d: %q", rr.Code, rr.Body.String())
+	}
+	if got := rr.Header().Get("Allow"); got != "OPTIONS, POST" {
+		t.Fatalf("unexpected Allow header %q", got)
+	}
+}
+
+func TestHandle_Options_AnsweredAutomatically(t *testing.T) {
+	s := newTestServer(t, config.ValidationNone, config.FallbackNone)
+
+	rr := httptest.NewRecorder()
+	s.handle(rr, httptest.NewRequest(http.MethodOptions, "http://example.com/items/1", nil))
+
+	if rr.Code != 204 {
+		t.Fatalf("expected 204, got %d", rr.Code)
+	}
+	if got := rr.Header().Get("Allow"); got != "GET, HEAD, OPTIONS" {
+		t.Fatalf("unexpected Allow header %q", got)
+	}
+
+	rr = httptest.NewRecorder()
+	s.handle(rr, httptest.NewRequest(http.MethodOptions, "http://example.com/unknown", nil))
+	if rr.Code != 404 {
+		t.Fatalf("expected 404 for unknown path, got %d", rr.Code)
+	}
+}
+
+func TestHandle_Security_FailureBodies(t *testing.T) {
+	disableScenarioForTests()
+
+	dir := t.TempDir()
+	specPath := writeFile(t, dir, "spec.json", `{
+	  "openapi":"3.0.3",
+	  "info":{"title":"t","version":"1"},
+	  "components":{"securitySchemes":{
+	    "basic":{"type":"http","scheme":"basic"},
+	    "oauth":{"type":"oauth2","flows":{"clientCredentials":{
+	      "tokenUrl":"https://example.com/token","scopes":{"write":"write"}
+	    }}}
+	  }},
+	  "paths":{
+	    "/items/{id}":{"get":{
+	      "security":[{"basic":[]}],
+	      "responses":{
+	        "200":{"description":"ok"},
+	        "401":{"description":"unauthorized","content":{"application/json":{"example":{"code":"from-spec"}}}}
+	      }
+	    }},
+	    "/items":{"post":{
+	      "security":[{"oauth":["write"]}],
+	      "responses":{"201":{"description":"created"}}
+	    }}
+	  }
+	}`)
+	writeFileWithDirs(t, dir, filepath.Join("items", "{id}", "GET.json"), `{"id":"123"}`)
+	writeFileWithDirs(t, dir, filepath.Join("items", "POST.json"), `{"status":201,"body":{"created":true}}`)
+	writeFileWithDirs(t, dir, filepath.Join("items", "POST.401.json"), `{"code":"from-sample"}`)
+
+	s, err := New(Config{
+		Port:         "0",
+		SpecPath:     specPath,
+		SamplesDir:   dir,
+		FallbackMode: config.FallbackNone,
+		Layout:       config.LayoutFolders,
+		Security: config.SecurityConfig{
+			Enabled:     true,
+			Credentials: map[string][]string{"basic": {"admin:secret"}, "oauth": {"reader()", "writer(write)"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	// 401 body from the spec example
+	rr := httptest.NewRecorder()
+	s.handle(rr, httptest.NewRequest(http.MethodGet, "http://example.com/items/1", nil))
+	if rr.Code != 401 || !strings.Contains(rr.Body.String(), "from-spec") {
+		t.Fatalf("expected 401 with spec example, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if got := rr.Header().Get("WWW-Authenticate"); got != `Basic realm="openapi-emulator"` {
+		t.Fatalf("unexpected WWW-Authenticate %q", got)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/items/1", nil)
+	req.SetBasicAuth("admin", "secret")
+	rr = httptest.NewRecorder()
+	s.handle(rr, req)
+	if rr.Code != 200 {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	// 401 body from the status sample
+	rr = httptest.NewRecorder()
+	s.handle(rr, httptest.NewRequest(http.MethodPost, "http://example.com/items", nil))
+	if rr.Code != 401 || !strings.Contains(rr.Body.String(), "from-sample") {
+		t.Fatalf("expected 401 with sample body, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	// 403 with the default body
+	req = httptest.NewRequest(http.MethodPost, "http://example.com/items", nil)
+	req.Header.Set("Authorization", "Bearer reader")
+	rr = httptest.NewRecorder()
+	s.handle(rr, req)
+	if rr.Code != 403 {
+		t.Fatalf("expected 403, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var body map[string]any
+	_ = json.Unmarshal(rr.Body.Bytes(), &body)
+	if body["title"] != "Forbidden" || body["scheme"] != "oauth" || body["detail"] != "token lacks scope write" {
+		t.Fatalf("unexpected body: %v", body)
+	}
+	if !strings.Contains(rr.Header().Get("WWW-Authenticate"), `error="insufficient_scope"`) {
+		t.Fatalf("expected insufficient_scope challenge, got %q", rr.Header().Get("WWW-Authenticate"))
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "http://example.com/items", nil)
+	req.Header.Set("Authorization", "Bearer writer")
+	rr = httptest.NewRecorder()
+	s.handle(rr, req)
+	if rr.Code != 201 {
+		t.Fatalf("expected 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestHandle_Security_SpecFailureMediaTypeAndHeaders(t *testing.T) {
+	disableScenarioForTests()
+
+	dir := t.TempDir()
+	specPath := writeFile(t, dir, "spec.json", `{
+	  "openapi":"3.0.3",
+	  "info":{"title":"t","version":"1"},
+	  "components":{"securitySchemes":{"basic":{"type":"http","scheme":"basic"}}},
+	  "paths":{
+	    "/items/{id}":{"get":{
+	      "security":[{"basic":[]}],
+	      "responses":{
+	        "200":{"description":"ok"},
+	        "401":{
+	          "description":"unauthorized",
+	          "headers":{
+	            "WWW-Authenticate":{"schema":{"type":"string"},"example":"Basic realm=\"upstream\""},
+	            "X-Request-Id":{"schema":{"type":"string"},"example":"req-1"}
+	          },
+	          "content":{"application/problem+json":{"example":{"title":"unauthorized"}}}
+	        }
+	      }
+	    }}
+	  }
+	}`)
+
+	s, err := New(Config{
+		Port:         "0",
+		SpecPath:     specPath,
+		SamplesDir:   dir,
+		FallbackMode: config.FallbackNone,
+		Layout:       config.LayoutFolders,
+		Security:     config.SecurityConfig{Enabled: true},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	rr := httptest.NewRecorder()
+	s.handle(rr, httptest.NewRequest(http.MethodGet, "http://example.com/items/1", nil))
+	if rr.Code != 401 || strings.TrimSpace(rr.Body.String()) != `{"title":"unauthorized"}` {
+		t.Fatalf("unexpected response %d %s", rr.Code, rr.Body.String())
+	}
+	for name, want := range map[string]string{
+		"Content-Type":     "application/problem+json",
+		"WWW-Authenticate": `Basic realm="upstream"`,
+		"X-Request-Id":     "req-1",
+	} {
+		if got := rr.Header().Values(name); len(got) != 1 || got[0] != want {
+			t.Fatalf("expected %s %q, got %q", name, want, got)
+		}
+	}
+}
+
+func TestHandle_Security_Swagger2FailureExamples(t *testing.T) {
+	disableScenarioForTests()
+
+	dir := t.TempDir()
+	specPath := writeFile(t, dir, "swagger.json", `{
+	  "swagger":"2.0",
+	  "info":{"title":"t","version":"1"},
+	  "securityDefinitions":{"key":{"type":"apiKey","name":"X-Key","in":"header"}},
+	  "paths":{
+	    "/items/{id}":{"get":{
+	      "security":[{"key":[]}],
+	      "parameters":[{"name":"id","in":"path","required":true,"type":"string"}],
+	      "responses":{
+	        "200":{"description":"ok","schema":{"type":"object"}},
+	        "401":{"description":"unauthorized","examples":{"application/json":{"code":"from-swagger2"}}}
+	      }
+	    }}
+	  }
+	}`)
+
+	s, err := New(Config{
+		Port:         "0",
+		SpecPath:     specPath,
+		SamplesDir:   dir,
+		FallbackMode: config.FallbackNone,
+		Layout:       config.LayoutFolders,
+		Security:     config.SecurityConfig{Enabled: true},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	rr := httptest.NewRecorder()
+	s.handle(rr, httptest.NewRequest(http.MethodGet, "http://example.com/items/1", nil))
+	if rr.Code != 401 || strings.TrimSpace(rr.Body.String()) != `{"code":"from-swagger2"}` {
+		t.Fatalf("expected 401 with the 2.0 example, got %d %s", rr.Code, rr.Body.String())
+	}
+	if ct := rr.Header().Get("content-type"); ct != "application/json" {
+		t.Fatalf("unexpected content-type %q", ct)
+	}
+}
+
+func TestHandle_Security_DisabledIgnoresRequirements(t *testing.T) {
+	disableScenarioForTests()
+
+	dir := t.TempDir()
+	spec := strings.Replace(minimalSpec(), `"get":{`, `"get":{"security":[{"key":[]}],`, 1)
+	spec = strings.Replace(spec, `"paths":{`,
+		`"components":{"securitySchemes":{"key":{"type":"apiKey","name":"X-Key","in":"header"}}},"paths":{`, 1)
+	specPath := writeFile(t, dir, "spec.json", spec)
+	writeFileWithDirs(t, dir, filepath.Join("items", "{id}", "GET.json"), `{"id":1}`)
+
+	for _, enabled := range []bool{false, true} {
+		s, err := New(Config{
+			Port:         "0",
+			SpecPath:     specPath,
+			SamplesDir:   dir,
+			FallbackMode: config.FallbackNone,
+			Layout:       config.LayoutFolders,
+			Security:     config.SecurityConfig{Enabled: enabled},
+		})
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+
+		rr := httptest.NewRecorder()
+		s.handle(rr, httptest.NewRequest(http.MethodGet, "http://example.com/items/1", nil))
+		want := 200
+		if enabled {
+			want = 401
+		}
+		if rr.Code != want {
+			t.Fatalf("enabled=%v: expected %d, got %d: %s", enabled, want, rr.Code, rr.Body.String())
+		}
+	}
+}
+
+func TestHandle_ContentNegotiation_415And406(t *testing.T) {
+	s := newTestServer(t, config.ValidationRequired, config.FallbackNone)
+
+	req := httptest.NewRequest(http.MethodPost, "http://example.com/items", strings.NewReader("x"))
+	req.Header.Set("Content-Type", "text/plain")
+	rr := httptest.NewRecorder()
+	s.handle(rr, req)
+	if rr.Code != 415 {
+		t.Fatalf("expected 415, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var body map[string]any
+	_ = json.Unmarshal(rr.Body.Bytes(), &body)
+	if supported, _ := body["supported"].([]any); len(supported) != 1 || supported[0] != "application/json" {
+		t.Fatalf("unexpected body: %v", body)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "http://example.com/items/1", nil)
+	req.Header.Set("Accept", "text/html")
+	rr = httptest.NewRecorder()
+	s.handle(rr, req)
+	if rr.Code != 406 {
+		t.Fatalf("expected 406, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "http://example.com/items/1", nil)
+	req.Header.Set("Accept", "text/html, application/json;q=0.5")
+	rr = httptest.NewRecorder()
+	s.handle(rr, req)
+	if rr.Code != 200 {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	// negotiation does not depend on request validation
+	s = newTestServer(t, config.ValidationNone, config.FallbackNone)
+	req = httptest.NewRequest(http.MethodPost, "http://example.com/items", strings.NewReader("x"))
+	req.Header.Set("Content-Type", "text/plain")
+	rr = httptest.NewRecorder()
+	s.handle(rr, req)
+	if rr.Code != 415 {
+		t.Fatalf("expected 415, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	// no checks in lenient mode
+	s.cfg.Negotiation = config.NegotiationLenient
+	rr = httptest.NewRecorder()
+	s.handle(rr, req)
+	if rr.Code != 201 {
+		t.Fatalf("expected 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	req = httptest.NewRequest(http.MethodGet, "http://example.com/items/1", nil)
+	req.Header.Set("Accept", "text/html")
+	rr = httptest.NewRecorder()
+	s.handle(rr, req)
+	if rr.Code != 200 || rr.Header().Get("content-type") != "application/json" {
+		t.Fatalf("expected 200 json, got %d %q", rr.Code, rr.Header().Get("content-type"))
+	}
+}
+
+func TestHandle_ContentNegotiation_AgainstServedResponse(t *testing.T) {
+	disableScenarioForTests()
+
+	dir := t.TempDir()
+	spec := strings.Replace(minimalSpec(), `"200":{
+				"description":"ok",`, `"409":{
+				"description":"conflict",
+				"content":{"application/problem+json":{"example":{"title":"conflict"}}}
+			  },
+			  "423":{
+				"description":"locked",
+				"content":{"text/plain":{"example":"locked"}}
+			  },
+			  "200":{
+				"description":"ok",`, 1)
+	specPath := writeFile(t, dir, "spec.json", spec)
+	writeFileWithDirs(t, dir, filepath.Join("items", "{id}", "GET.json"), `{"status":423,"headers":{"content-type":"text/plain"},"body":"locked"}`)
+
+	s, err := New(Config{
+		Port:           "0",
+		SpecPath:       specPath,
+		SamplesDir:     dir,
+		FallbackMode:   config.FallbackOpenAPIExample,
+		ValidationMode: config.ValidationRequired,
+		Layout:         config.LayoutFolders,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	get := func(accept, prefer string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "http://example.com/items/1", nil)
+		req.Header.Set("Accept", accept)
+		if prefer != "" {
+			req.Header.Set("Prefer", prefer)
+		}
+		rr := httptest.NewRecorder()
+		s.handle(rr, req)
+		return rr
+	}
+
+	// the sample is an error response: served in its own media type
+	if rr := get("application/json", ""); rr.Code != 423 || rr.Body.String() != `"locked"` {
+		t.Fatalf("expected the 423 sample, got %d %s", rr.Code, rr.Body.String())
+	}
+
+	// Prefer: the preferred response is negotiated, not the best one
+	if rr := get("application/problem+json", "code=409"); rr.Code != 409 || rr.Header().Get("content-type") != "application/problem+json" {
+		t.Fatalf("expected the 409 problem, got %d %q %s", rr.Code, rr.Header().Get("content-type"), rr.Body.String())
+	}
+	if rr := get("text/html", "code=200"); rr.Code != 406 {
+		t.Fatalf("expected 406 for the preferred 200, got %d %s", rr.Code, rr.Body.String())
+	}
+	if rr := get("application/json", "code=200"); rr.Code != 200 {
+		t.Fatalf("expected 200, got %d %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestHandle_ContentNegotiation_ChoosesResponseMediaType(t *testing.T) {
+	disableScenarioForTests()
+
+	dir := t.TempDir()
+	spec := strings.Replace(minimalSpec(), `"application/json":{
+					"example":{"id":"example"}
+				  }`, `"application/json":{
+					"example":{"id":"example"}
+				  },
+				  "application/vnd.api+json":{"schema":{"type":"object"}},
+				  "application/xml":{"example":"<id>example</id>"}`, 1)
+	specPath := writeFile(t, dir, "spec.json", spec)
+	writeFileWithDirs(t, dir, filepath.Join("items", "{id}", "GET.json"), `{"id":"123"}`)
+
+	s, err := New(Config{
+		Port:           "0",
+		SpecPath:       specPath,
+		SamplesDir:     dir,
+		FallbackMode:   config.FallbackOpenAPIExample,
+		ValidationMode: config.ValidationRequired,
+		Layout:         config.LayoutFolders,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	get := func(path, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "http://example.com"+path, nil)
+		req.Header.Set("Accept", accept)
+		rr := httptest.NewRecorder()
+		s.handle(rr, req)
+		return rr
+	}
+
+	// the JSON sample is labelled with the negotiated JSON media type
+	rr := get("/items/1", "application/vnd.api+json")
+	if rr.Code != 200 || rr.Header().Get("content-type") != "application/vnd.api+json" || rr.Body.String() != `{"id":"123"}` {
+		t.Fatalf("unexpected response %d %q: %s", rr.Code, rr.Header().Get("content-type"), rr.Body.String())
+	}
+
+	// the spec fallback serves the example of the negotiated media type
+	if err := os.Remove(filepath.Join(dir, "items", "{id}", "GET.json")); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	rr = get("/items/1", "application/xml")
+	if rr.Code != 200 || rr.Header().Get("content-type") != "application/xml" || rr.Body.String() != "<id>example</id>" {
+		t.Fatalf("unexpected response %d %q: %s", rr.Code, rr.Header().Get("content-type"), rr.Body.String())
+	}
+}
+
+func TestHandle_Fallback_BranchHeaderSelectsOneOf(t *testing.T) {
+	disableScenarioForTests()
+
+	dir := t.TempDir()
+	spec := strings.Replace(minimalSpec(), `"application/json":{
+					"example":{"id":"example"}
+				  }`, `"application/json":{"schema":{
+					"oneOf":[
+					  {"title":"scan","type":"object","properties":{"scanId":{"type":"string"}}},
+					  {"title":"task","type":"object","properties":{"taskId":{"type":"integer"}}}
+					]
+				  }}`, 1)
+	specPath := writeFile(t, dir, "spec.json", spec)
+
+	s, err := New(Config{
+		Port:           "0",
+		SpecPath:       specPath,
+		SamplesDir:     dir,
+		FallbackMode:   config.FallbackOpenAPIExample,
+		ValidationMode: config.ValidationRequired,
+		Layout:         config.LayoutFolders,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	get := func(branch string) string {
+		req := httptest.NewRequest(http.MethodGet, "http://example.com/items/1", nil)
+		if branch != "" {
+			req.Header.Set("X-Emulator-Branch", branch)
+		}
+		rr := httptest.NewRecorder()
+		s.handle(rr, req)
+		return strings.TrimSpace(rr.Body.String())
+	}
+
+	if body := get(""); body != `{"scanId":"string"}` {
+		t.Fatalf("expected first branch, got %s", body)
+	}
+	if body := get("task"); body != `{"taskId":0}` {
+		t.Fatalf("expected selected branch, got %s", body)
+	}
+}
+
+func TestHandle_Fallback_FakeGeneratorIsSeeded(t *testing.T) {
+	disableScenarioForTests()
+
+	dir := t.TempDir()
+	spec := strings.Replace(minimalSpec(), `"application/json":{
+					"example":{"id":"example"}
+				  }`, `"application/json":{"schema":{"type":"object","properties":{
+					"id":{"type":"string","format":"uuid"},
+					"name":{"type":"string"},
+					"count":{"type":"integer"}
+				  }}}`, 1)
+	specPath := writeFile(t, dir, "spec.json", spec)
+
+	newServer := func(seed string) *Server {
+		s, err := New(Config{
+			Port:           "0",
+			SpecPath:       specPath,
+			SamplesDir:     dir,
+			FallbackMode:   config.FallbackOpenAPIExample,
+			ValidationMode: config.ValidationRequired,
+			Layout:         config.LayoutFolders,
+			Generator:      config.GeneratorConfig{Mode: config.GeneratorFake, Seed: seed},
+		})
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+		return s
+	}
+	get := func(s *Server, path, seed string) string {
+		req := httptest.NewRequest(http.MethodGet, "http://example.com"+path, nil)
+		if seed != "" {
+			req.Header.Set("X-Emulator-Seed", seed)
+		}
+		rr := httptest.NewRecorder()
+		s.handle(rr, req)
+		if rr.Code != 200 {
+			t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+		}
+		return rr.Body.String()
+	}
+
+	s := newServer("ci")
+	first := get(s, "/items/1", "")
+	if again := get(newServer("ci"), "/items/1", ""); again != first {
+		t.Fatalf("expected the same body for the same request:\n%s\n%s", first, again)
+	}
+	if other := get(s, "/items/2", ""); other == first {
+		t.Fatalf("expected a different body for another id: %s", other)
+	}
+	if other := get(newServer("nightly"), "/items/1", ""); other == first {
+		t.Fatalf("expected a different body for another seed: %s", other)
+	}
+	if header := get(newServer("nightly"), "/items/1", "ci"); header != first {
+		t.Fatalf("expected the seed header to override the configured seed:\n%s\n%s", first, header)
+	}
+}
+
 func newTestServer(t *testing.T, validation config.ValidationMode, fallback config.FallbackMode) *Server {
 	t.Helper()
 	disableScenarioForTests()
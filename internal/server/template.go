@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/ozgen/openapi-emulator/internal/openapi"
+	"github.com/ozgen/openapi-emulator/internal/samples"
+	"github.com/sirupsen/logrus"
+)
+
+// renderSample renders the templates of resp for r when templating is
+// enabled. A broken template is answered with a 500 problem, and false is
+// returned.
+func (s *Server) renderSample(w http.ResponseWriter, r *http.Request, snap *snapshot, match *openapi.RouteMatch, resp *samples.Response) bool {
+	if !s.cfg.Templating {
+		return true
+	}
+	err := resp.Render(templateData(r, match, resp.State))
+	if err == nil {
+		return true
+	}
+
+	s.log.WithError(err).WithFields(logrus.Fields{
+		"method":      r.Method,
+		"path":        r.URL.Path,
+		"swaggerPath": match.Route.Swagger,
+	}).Warn("failed to render sample template")
+	s.renderer.Render(w, r, snap.problem(match, 500, "Sample template error", err.Error(), map[string]any{
+		"method":      r.Method,
+		"path":        r.URL.Path,
+		"swaggerPath": match.Route.Swagger,
+	}))
+	return false
+}
+
+// templateData collects what sample templates see of r. Query params and
+// headers keep their first value; the body is decoded when it is JSON.
+func templateData(r *http.Request, match *openapi.RouteMatch, state string) *samples.TemplateData {
+	data := &samples.TemplateData{
+		Method:  r.Method,
+		Path:    match.Params,
+		Query:   map[string]string{},
+		Headers: map[string]string{},
+		State:   state,
+	}
+	if data.Path == nil {
+		data.Path = map[string]string{}
+	}
+	for k, v := range r.URL.Query() {
+		data.Query[k] = v[0]
+	}
+	for k, v := range r.Header {
+		data.Headers[k] = v[0]
+	}
+
+	if r.Body == nil {
+		return data
+	}
+	b, err := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(bytes.NewReader(b))
+	if err != nil || len(bytes.TrimSpace(b)) == 0 {
+		return data
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var body any
+	if dec.Decode(&body) == nil {
+		data.Body = body
+	}
+	return data
+}
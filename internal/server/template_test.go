@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ozgen/openapi-emulator/config"
+)
+
+func TestHandle_Templating(t *testing.T) {
+	disableScenarioForTests()
+
+	dir := t.TempDir()
+	specPath := writeFile(t, dir, "spec.json", minimalSpec())
+	writeFileWithDirs(t, dir, filepath.Join("items", "{id}", "GET.json"), `{
+		"headers": {"X-Echo": "{{index .Headers \"X-Trace\"}}"},
+		"body": {"id": "{{.Path.id}}", "verbose": "{{.Query.verbose}}"}
+	}`)
+	writeFileWithDirs(t, dir, filepath.Join("items", "POST.json"),
+		`{"status":201,"body":{"name":"{{.Body.name}}","first":"{{jsonPath .Body \"tags[0]\"}}"}}`)
+
+	newServer := func(templating bool) *Server {
+		s, err := New(Config{
+			Port:           "0",
+			SpecPath:       specPath,
+			SamplesDir:     dir,
+			FallbackMode:   config.FallbackOpenAPIExample,
+			ValidationMode: config.ValidationRequired,
+			Layout:         config.LayoutFolders,
+			Templating:     templating,
+		})
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+		return s
+	}
+
+	s := newServer(true)
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/items/7?verbose=1", nil)
+	req.Header.Set("X-Trace", "abc")
+	rr := httptest.NewRecorder()
+	s.handle(rr, req)
+	if rr.Code != 200 || strings.TrimSpace(rr.Body.String()) != `{"id":"7","verbose":"1"}` {
+		t.Fatalf("unexpected response %d %s", rr.Code, rr.Body.String())
+	}
+	if got := rr.Header().Get("X-Echo"); got != "abc" {
+		t.Fatalf("expected templated header, got %q", got)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "http://example.com/items", strings.NewReader(`{"name":"scan","tags":["a","b"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	rr = httptest.NewRecorder()
+	s.handle(rr, req)
+	if rr.Code != 201 || strings.TrimSpace(rr.Body.String()) != `{"first":"a","name":"scan"}` {
+		t.Fatalf("unexpected response %d %s", rr.Code, rr.Body.String())
+	}
+
+	rr = httptest.NewRecorder()
+	newServer(false).handle(rr, httptest.NewRequest(http.MethodGet, "http://example.com/items/7", nil))
+	if !strings.Contains(rr.Body.String(), "{{.Path.id}}") {
+		t.Fatalf("expected the sample as it is without templating, got %s", rr.Body.String())
+	}
+}
+
+func TestHandle_Templating_ScenarioStateAndErrors(t *testing.T) {
+	config.Envs.Scenario.Enabled = true
+	defer disableScenarioForTests()
+
+	dir := t.TempDir()
+	specPath := writeFile(t, dir, "spec.json", minimalSpec())
+	writeFileWithDirs(t, dir, filepath.Join("items", "{id}", "scenario.json"), `{
+		"version": 1,
+		"mode": "step",
+		"key": {"pathParam": "id"},
+		"sequence": [
+			{"state": "running", "file": "GET.running.json"},
+			{"state": "broken", "file": "GET.broken.json"}
+		],
+		"behavior": {"advanceOn": [{"method": "GET"}], "repeatLast": true}
+	}`)
+	writeFileWithDirs(t, dir, filepath.Join("items", "{id}", "GET.running.json"), `{"state":"{{.State}}"}`)
+	writeFileWithDirs(t, dir, filepath.Join("items", "{id}", "GET.broken.json"), `{"id": {{.Path.id}`)
+
+	s, err := New(Config{
+		Port:           "0",
+		SpecPath:       specPath,
+		SamplesDir:     dir,
+		FallbackMode:   config.FallbackOpenAPIExample,
+		ValidationMode: config.ValidationRequired,
+		Layout:         config.LayoutFolders,
+		Templating:     true,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	get := func() *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		s.handle(rr, httptest.NewRequest(http.MethodGet, "http://example.com/items/1", nil))
+		return rr
+	}
+
+	if rr := get(); rr.Code != 200 || strings.TrimSpace(rr.Body.String()) != `{"state":"running"}` {
+		t.Fatalf("unexpected response %d %s", rr.Code, rr.Body.String())
+	}
+
+	rr := get()
+	if rr.Code != 500 {
+		t.Fatalf("expected 500 for a broken template, got %d %s", rr.Code, rr.Body.String())
+	}
+	var m map[string]any
+	if err := json.Unmarshal(rr.Body.Bytes(), &m); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if m["title"] != "Sample template error" || !strings.Contains(m["detail"].(string), "template") {
+		t.Fatalf("unexpected problem %v", m)
+	}
+}
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package server
+
+import (
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// fileWatcher detects changes below a set of files and directories by
+// polling. Polling is used instead of inotify because change events are not
+// reliably delivered through container bind mounts.
+type fileWatcher struct {
+	roots []string
+	last  map[string]fileStamp
+}
+
+func newFileWatcher(roots ...string) *fileWatcher {
+	w := &fileWatcher{roots: roots}
+	w.last = w.scan()
+	return w
+}
+
+// poll returns the paths added, modified or removed since the previous poll.
+func (w *fileWatcher) poll() []string {
+	cur := w.scan()
+
+	var changed []string
+	for p, st := range cur {
+		if prev, ok := w.last[p]; !ok || prev != st {
+			changed = append(changed, p)
+		}
+	}
+	for p := range w.last {
+		if _, ok := cur[p]; !ok {
+			changed = append(changed, p)
+		}
+	}
+
+	w.last = cur
+	sort.Strings(changed)
+	return changed
+}
+
+func (w *fileWatcher) scan() map[string]fileStamp {
+	out := map[string]fileStamp{}
+	for _, root := range w.roots {
+		if root == "" {
+			continue
+		}
+		// missing roots and unreadable entries are skipped; they show up as
+		// changes once they become readable
+		_ = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			out[p] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+			return nil
+		})
+	}
+	return out
+}
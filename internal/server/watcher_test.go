@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2026 Greenbone AG
+//
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileWatcher_DetectsAddModifyRemove(t *testing.T) {
+	dir := t.TempDir()
+	spec := writeFile(t, dir, "spec.json", `{}`)
+	samplesDir := filepath.Join(dir, "sample")
+	sample := writeFileWithDirs(t, samplesDir, filepath.Join("items", "GET.json"), `{}`)
+
+	w := newFileWatcher(spec, samplesDir)
+
+	if got := w.poll(); len(got) != 0 {
+		t.Fatalf("expected no changes, got %v", got)
+	}
+
+	added := writeFileWithDirs(t, samplesDir, filepath.Join("items", "POST.json"), `{}`)
+	if got := w.poll(); len(got) != 1 || got[0] != added {
+		t.Fatalf("expected %q added, got %v", added, got)
+	}
+
+	writeFile(t, dir, "spec.json", `{"changed":true}`)
+	if got := w.poll(); len(got) != 1 || got[0] != spec {
+		t.Fatalf("expected %q modified, got %v", spec, got)
+	}
+
+	if err := os.Remove(sample); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if got := w.poll(); len(got) != 1 || got[0] != sample {
+		t.Fatalf("expected %q removed, got %v", sample, got)
+	}
+}
+
+func TestFileWatcher_MissingRootIgnored(t *testing.T) {
+	dir := t.TempDir()
+	missing := filepath.Join(dir, "not-yet")
+
+	w := newFileWatcher(missing, "")
+	if got := w.poll(); len(got) != 0 {
+		t.Fatalf("expected no changes, got %v", got)
+	}
+
+	p := writeFileWithDirs(t, missing, "GET.json", `{}`)
+	if got := w.poll(); len(got) != 1 || got[0] != p {
+		t.Fatalf("expected %q once root appears, got %v", p, got)
+	}
+}